@@ -1,73 +1,521 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/pprof"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/AClarkie/k8s-tui/pkg/controller"
 	model "github.com/AClarkie/k8s-tui/pkg/model"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+var logLevel = slog.LevelInfo
+
+func init() {
+	flag.TextVar(&logLevel, "log-level", slog.LevelInfo, "minimum level of the controller logs: debug, info, warn or error")
+	flag.TextVar(&logLevel, "v", slog.LevelInfo, "shorthand for -log-level")
+	flag.Func("exclude-namespace", "comma separated namespaces whose objects are ignored, e.g. kube-system,kube-public, can be repeated", func(namespaces string) error {
+		for _, namespace := range strings.Split(namespaces, ",") {
+			if namespace = strings.TrimSpace(namespace); namespace != "" {
+				excludedNamespaces = append(excludedNamespaces, namespace)
+			}
+		}
+		return nil
+	})
+	flag.Func("as-group", "group to impersonate along with -as, can be repeated", func(group string) error {
+		asGroups = append(asGroups, group)
+		return nil
+	})
+}
+
+// asGroups are the groups impersonated by the -as user
+var asGroups []string
+
+// excludedNamespaces are the namespaces set with -exclude-namespace
+var excludedNamespaces []string
+
+var (
+	retryBaseDelay = flag.Duration("retry-base-delay", 5*time.Millisecond, "initial delay before retrying a failed sync")
+	retryMaxDelay  = flag.Duration("retry-max-delay", 1000*time.Second, "maximum delay between retries of a failed sync")
+	workers        = flag.Int("workers", 1, "number of workers syncing the objects of each resource")
+	listPageSize   = flag.Int64("list-page-size", 0, "number of objects fetched per page by the initial lists, lower it to ease the load of large clusters (client-go's default of 500 when 0)")
+	metricsAddr    = flag.String("metrics-addr", "", "address to serve Prometheus metrics on, e.g. :9090 (disabled when empty)")
+	pprofAddr      = flag.String("pprof-addr", "", "address to serve runtime profiles on, e.g. localhost:6060 (disabled when empty)")
+	logFile        = flag.String("log-file", "", "file to append controller logs to (discarded when empty)")
+	columns        = flag.String("columns", model.DefaultColumns, "comma separated deployment columns: namespace, name, ready, age, label:<key> or anno:<key>")
+	groupBy        = flag.String("group-by", "", "label key to group deployments by, or namespace to group them by namespace along with their resource quotas")
+	columnPadding  = flag.Int("column-padding", 2, "number of spaces between table columns")
+	alignRight     = flag.Bool("align-right", false, "right align the table cells")
+	resourceName   = flag.String("resource", "", "resource tab to open at launch, e.g. pods (deployments, or the last used tab, when empty)")
+	once           = flag.Bool("once", false, "print the table once and exit instead of running the TUI")
+	outputWidth    = flag.Int("output-width", 0, "width to fit the -once table to (the terminal width, or no limit when not a terminal, when 0)")
+	noHeaders      = flag.Bool("no-headers", false, "leave the column titles out of the -once table")
+	eventDriven    = flag.Bool("event-driven", false, "redraw only when the cluster changes or a key is pressed instead of every second")
+	compact        = flag.Bool("compact", false, "print a single refreshing summary line instead of running the TUI")
+	noState        = flag.Bool("no-state", false, "do not restore or save the tab, sort mode and filter between runs")
+	timeFormat     = flag.String("time-format", model.DefaultTimeFormat, "format of the last refresh time in the status bar: 24h, 12h, 24h-date, 12h-date or relative")
+	verboseAge     = flag.Bool("verbose-age", false, "show ages in their two largest units, e.g. 3d4h instead of 3d")
+	hideAnnos      = flag.String("hide-annotations", model.DefaultHiddenAnnotations, "comma separated annotations hidden from the detail view, a trailing * matches any suffix")
+	showAnnos      = flag.String("show-annotations", "", "comma separated annotations shown in the detail view even if -hide-annotations matches them")
+	unhealthyFirst = flag.Bool("unhealthy-first", false, "sort unhealthy deployments to the top")
+	fieldSelector  = flag.String("field-selector", "", "field selector restricting every watch, e.g. metadata.namespace!=kube-system")
+	nsLabels       = flag.String("watch-namespace-labels", "", "only watch namespaces matching this label selector, e.g. env=prod")
+	kubeconfig     = flag.String("kubeconfig", "", "path to the kubeconfig file (the files in $KUBECONFIG or ~/.kube/config when empty)")
+	kubeContext    = flag.String("context", "", "kubeconfig context to use (the current context when empty)")
+	allNamespaces  = flag.Bool("A", false, "watch every namespace instead of the namespace of the context")
+	defaultNs      = flag.String("default-namespace", meta_v1.NamespaceDefault, "namespace to watch when the context doesn't set one")
+	asUser         = flag.String("as", "", "user to impersonate, the watches and actions then run with their permissions")
+	deploymentName = flag.String("name", "", "only watch the deployment with this name and show a dashboard of it")
+	fieldManager   = flag.String("field-manager", controller.DefaultFieldManager, "field manager name used by server-side apply")
+	trimObjects    = flag.Bool("trim-objects", false, "drop the managed fields and last applied configuration of cached objects to save memory on large clusters")
+	customGVR      = flag.String("gvr", "", "custom resource to show in an extra tab, as group/version/resource, e.g. argoproj.io/v1alpha1/rollouts")
+	readOnly       = flag.Bool("read-only", false, "disable every action changing the cluster, such as delete, scale and edit")
+	// client-go defaults to 5 QPS with a burst of 10, which throttles the
+	// initial lists of every informer on large clusters
+	qps   = flag.Float64("qps", 50, "maximum queries per second to the API server")
+	burst = flag.Int("burst", 100, "maximum burst of queries to the API server above -qps")
+
+	// The timeout bounds whole requests, watches included: a watch stream
+	// outliving it is cut and has to be reopened by its informer. The
+	// informers end their watches after 5 to 10 minutes anyway, so a timeout
+	// above 10m only cuts the streams which hang.
+	requestTimeout = flag.Duration("request-timeout", 0, "maximum duration of any request to the API server, watches included, e.g. 15m (no limit when 0)")
+
+	// These override the TLS settings of the kubeconfig cluster, for dev
+	// clusters with self-signed certificates
+	insecureSkipTLSVerify = flag.Bool("insecure-skip-tls-verify", false, "do not verify the certificate of the API server, leaving the connection open to eavesdropping")
+	certificateAuthority  = flag.String("certificate-authority", "", "path to the certificate authority file to verify the API server with, instead of the one of the kubeconfig")
 )
 
 func main() {
-	homedir, err := os.UserHomeDir()
-	if err != nil {
-		fmt.Printf("Alas, there's been an error: %v", err)
+	flag.Parse()
+
+	if len(asGroups) > 0 && *asUser == "" {
+		fmt.Printf("Alas, there's been an error: -as-group requires -as")
+		os.Exit(1)
+	}
+	if *workers < 1 {
+		fmt.Printf("Alas, there's been an error: -workers must be at least 1")
 		os.Exit(1)
 	}
+	if *listPageSize < 0 {
+		fmt.Printf("Alas, there's been an error: -list-page-size must not be negative")
+		os.Exit(1)
+	}
+	if *requestTimeout < 0 {
+		fmt.Printf("Alas, there's been an error: -request-timeout must not be negative")
+		os.Exit(1)
+	}
+	if *insecureSkipTLSVerify && *certificateAuthority != "" {
+		fmt.Printf("Alas, there's been an error: -insecure-skip-tls-verify and -certificate-authority are mutually exclusive")
+		os.Exit(1)
+	}
+	impersonate := rest.ImpersonationConfig{UserName: *asUser, Groups: asGroups}
 
 	// Create a new controller
 	// Build clientset
-	kubeconfig := filepath.Join(homedir, ".kube", "config")
-	clientset, err := buildClientset(&kubeconfig)
+	cluster, err := buildClientset(*kubeconfig, *kubeContext, impersonate, float32(*qps), *burst, *requestTimeout, *insecureSkipTLSVerify, *certificateAuthority)
 	if err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 		os.Exit(1)
 	}
 
+	if *metricsAddr != "" {
+		if err := serveMetrics(*metricsAddr); err != nil {
+			fmt.Printf("Alas, there's been an error: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	if *pprofAddr != "" {
+		if err := servePprof(*pprofAddr); err != nil {
+			fmt.Printf("Alas, there's been an error: %v", err)
+			os.Exit(1)
+		}
+	}
+
 	stop := make(chan struct{})
 	defer close(stop)
 
-	controller := controller.NewController(clientset.AppsV1())
+	if _, err := fields.ParseSelector(*fieldSelector); err != nil {
+		fmt.Printf("Alas, there's been an error: invalid field selector, got err: %v", err)
+		os.Exit(1)
+	}
+
+	if _, err := labels.Parse(*nsLabels); err != nil {
+		fmt.Printf("Alas, there's been an error: invalid namespace label selector, got err: %v", err)
+		os.Exit(1)
+	}
+
+	controllerOpts := []controller.Option{
+		controller.WithRetryBackoff(*retryBaseDelay, *retryMaxDelay),
+		controller.WithLogLevel(logLevel),
+		controller.WithFieldSelector(*fieldSelector),
+		controller.WithNamespaceSelector(*nsLabels),
+		controller.WithExcludedNamespaces(excludedNamespaces),
+		// A namespace selector spans namespaces, like -A
+		controller.WithNamespace(resolveNamespace(cluster.namespace, *defaultNs, *allNamespaces || *nsLabels != "")),
+		controller.WithReadOnly(*readOnly),
+		controller.WithDeploymentName(*deploymentName),
+		controller.WithFieldManager(*fieldManager),
+		controller.WithTrimmedObjects(*trimObjects),
+		controller.WithWorkers(*workers),
+		controller.WithListPageSize(*listPageSize),
+		controller.WithMetrics(cluster.metrics),
+	}
+	if *customGVR != "" {
+		gvr, err := parseGVR(*customGVR)
+		if err != nil {
+			fmt.Printf("Alas, there's been an error: %v", err)
+			os.Exit(1)
+		}
+		namespaced, err := resourceScope(cluster.clientset.Discovery(), gvr)
+		if err != nil {
+			fmt.Printf("Alas, there's been an error: %v", err)
+			os.Exit(1)
+		}
+		controllerOpts = append(controllerOpts, controller.WithCustomResource(cluster.dynamic, gvr, namespaced))
+	}
+	// stdout belongs to the TUI and the -once and -compact output, so the
+	// logs only go to a file
+	if *logFile != "" {
+		f, err := os.OpenFile(*logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			fmt.Printf("Alas, there's been an error: %v", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		controllerOpts = append(controllerOpts, controller.WithLogOutput(f))
+	}
+
+	controller := controller.NewController(cluster.clientset, controllerOpts...)
 	go func() {
 		go controller.Run(stop)
 	}()
 
-	model, err := model.InitialModel(controller)
+	if *compact {
+		done := make(chan struct{})
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-signals
+			close(done)
+		}()
+		model.RunCompact(controller, os.Stdout, done)
+		return
+	}
+
+	var modelOpts []model.Option
+	statePath := stateFile()
+	if !*noState && statePath != "" {
+		// A missing or corrupt state file leaves the defaults in place
+		if state, err := model.LoadState(statePath); err == nil {
+			modelOpts = append(modelOpts, model.WithState(state))
+		}
+	}
+	modelOpts = append(modelOpts,
+		model.WithColumns(*columns),
+		model.WithGroupBy(*groupBy),
+		model.WithTablePadding(*columnPadding, ' '),
+		model.WithAlignRight(*alignRight),
+		model.WithUnhealthyFirst(*unhealthyFirst),
+		model.WithResource(*resourceName),
+		model.WithContext(cluster.context),
+		model.WithImpersonation(*asUser, asGroups),
+		model.WithInsecureTLS(*insecureSkipTLSVerify),
+		model.WithEventDriven(*eventDriven),
+		model.WithFocus(*deploymentName),
+		model.WithVerboseAge(*verboseAge),
+		model.WithTimeFormat(*timeFormat),
+		model.WithAnnotationFilter(*hideAnnos, *showAnnos),
+	)
+
+	// The TUI would garble piped or redirected output
+	if !*once && !isatty.IsTerminal(os.Stdout.Fd()) && !isatty.IsCygwinTerminal(os.Stdout.Fd()) {
+		fmt.Fprintln(os.Stderr, "Stdout is not a terminal, printing the table once as with -once.")
+		*once = true
+	}
+
+	if *once {
+		width := *outputWidth
+		if width == 0 && isatty.IsTerminal(os.Stdout.Fd()) {
+			// Not knowing the size only means not truncating
+			width, _, _ = term.GetSize(int(os.Stdout.Fd()))
+		}
+		modelOpts = append(modelOpts, model.WithWidth(width))
+		if err := model.PrintOnce(controller, os.Stdout, !*noHeaders, modelOpts...); err != nil {
+			fmt.Printf("Alas, there's been an error: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *noHeaders {
+		fmt.Fprintln(os.Stderr, "Ignoring -no-headers, it only applies to -once.")
+	}
+
+	m, err := model.InitialModel(controller, modelOpts...)
 	if err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 		os.Exit(1)
 	}
-	p := tea.NewProgram(model)
-	if _, err := p.Run(); err != nil {
+	p := tea.NewProgram(m)
+	final, err := p.Run()
+	if err != nil {
+		fmt.Printf("Alas, there's been an error: %v", err)
+		os.Exit(1)
+	}
+
+	if state, ok := model.FinalState(final); ok && !*noState && statePath != "" {
+		if err := model.SaveState(statePath, state); err != nil {
+			fmt.Printf("Alas, there's been an error: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	// Print the chosen object for the next command in the pipeline
+	if data, ok, err := model.SelectedJSON(final); ok {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Alas, there's been an error: %v", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	// Exit non zero when the controller could never have recovered
+	if err := controller.Err(); err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 		os.Exit(1)
 	}
 
 }
 
-// buildClientset creates a Kubernetes Clientset, if kubeconfig is empty then
-// the in cluster config will attempt to be used.
-func buildClientset(kubeconfig *string) (*kubernetes.Clientset, error) {
-	var (
-		config *rest.Config
-		err    error
-	)
-	// use the current context in kubeconfig
-	config, err = clientcmd.BuildConfigFromFlags("", *kubeconfig)
+// cluster holds the clients of the cluster and the kubeconfig context they
+// were configured from.
+type cluster struct {
+	clientset *kubernetes.Clientset
+	dynamic   dynamic.Interface
+	metrics   metricsclient.Interface
+	context   string // name of the context, empty in cluster
+	namespace string // namespace of the context, empty when it doesn't set one
+}
+
+// buildClientset creates a Kubernetes Clientset, a dynamic client and a
+// metrics client, along with the name and namespace of the kubeconfig context
+// they use. If kubeconfig is empty the files listed in $KUBECONFIG are
+// merged, falling back to ~/.kube/config and then to the in cluster config. A
+// non empty kubeContext overrides the current context, and every request is
+// made as the identity in impersonate when it names a user. Requests are rate
+// limited to qps with bursts of up to burst, and given up after timeout unless
+// it is zero. The certificate of the API server is verified with the
+// certificate authority file caFile when it is set, or not at all when
+// insecure is.
+func buildClientset(kubeconfig, kubeContext string, impersonate rest.ImpersonationConfig, qps float32, burst int, timeout time.Duration, insecure bool, caFile string) (*cluster, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+
+	raw, err := clientConfig.RawConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig, got err: %w", err)
+	}
+	contextName := raw.CurrentContext
+	if kubeContext != "" {
+		if _, ok := raw.Contexts[kubeContext]; !ok {
+			return nil, fmt.Errorf("context %q not found in kubeconfig", kubeContext)
+		}
+		contextName = kubeContext
+	}
+
+	config, err := clientConfig.ClientConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build config, got err: %s", err)
 	}
+	if impersonate.UserName != "" {
+		config.Impersonate = impersonate
+	}
+	config.QPS = qps
+	config.Burst = burst
+	config.Timeout = timeout
+
+	// client-go refuses to skip the verification while a certificate
+	// authority is configured
+	if insecure {
+		config.TLSClientConfig.Insecure = true
+		config.TLSClientConfig.CAFile = ""
+		config.TLSClientConfig.CAData = nil
+	}
+	if caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate authority, got err: %w", err)
+		}
+		config.TLSClientConfig.CAFile = ""
+		config.TLSClientConfig.CAData = data
+	}
+
+	// Fail fast rather than waiting forever for the informers to sync
+	if err := checkConnectivity(config); err != nil {
+		return nil, err
+	}
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure k8s client, got err: %w", err)
 	}
 
-	return clientset, nil
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure dynamic client, got err: %w", err)
+	}
+
+	metricsClient, err := metricsclient.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure metrics client, got err: %w", err)
+	}
+
+	// The in cluster config has no context
+	c := &cluster{clientset: clientset, dynamic: dynamicClient, metrics: metricsClient, context: contextName}
+	if context, ok := raw.Contexts[contextName]; ok {
+		c.namespace = context.Namespace
+	}
+	return c, nil
+}
+
+// resolveNamespace returns the namespace to watch at launch like kubectl
+// does: none, meaning all of them, when all is set, otherwise the namespace
+// of the context or fallback when the context doesn't set one.
+func resolveNamespace(contextNamespace, fallback string, all bool) string {
+	switch {
+	case all:
+		return ""
+	case contextNamespace != "":
+		return contextNamespace
+	default:
+		return fallback
+	}
+}
+
+// parseGVR parses a group/version/resource such as
+// argoproj.io/v1alpha1/rollouts. The group of the core resources is empty,
+// e.g. /v1/pods.
+func parseGVR(s string) (schema.GroupVersionResource, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid resource %q, expected group/version/resource", s)
+	}
+	return schema.GroupVersionResource{Group: parts[0], Version: parts[1], Resource: parts[2]}, nil
+}
+
+// resourceScope asks the API server whether gvr is namespaced, failing when
+// it isn't served at all.
+func resourceScope(client discovery.DiscoveryInterface, gvr schema.GroupVersionResource) (bool, error) {
+	list, err := client.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		return false, fmt.Errorf("failed to discover the resources of %s, got err: %w", gvr.GroupVersion(), err)
+	}
+	for _, r := range list.APIResources {
+		if r.Name == gvr.Resource {
+			return r.Namespaced, nil
+		}
+	}
+	return false, fmt.Errorf("resource %s is not served by the cluster", gvr)
+}
+
+// connectivityTimeout bounds the startup check of the API server.
+const connectivityTimeout = 5 * time.Second
+
+// checkConnectivity asks the API server for its version, failing with the
+// server address when it can't be reached within connectivityTimeout.
+func checkConnectivity(config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config.Timeout = connectivityTimeout
+
+	client, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to configure discovery client, got err: %w", err)
+	}
+	if _, err := client.ServerVersion(); err != nil {
+		// Impersonation is checked on every request, so this is where a
+		// missing impersonate permission shows up first
+		if user := config.Impersonate.UserName; user != "" && apierrors.IsForbidden(err) {
+			return fmt.Errorf("not allowed to impersonate %s, got err: %w", user, err)
+		}
+		return fmt.Errorf("cannot reach cluster at %s: %w", config.Host, err)
+	}
+	return nil
+}
+
+// stateFile returns the path of the UI state file under $XDG_STATE_HOME,
+// which defaults to ~/.local/state. It is empty when neither can be found.
+func stateFile() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "k8s-tui", "state.json")
+}
+
+// serveMetrics starts serving the controller metrics on addr in the
+// background. The listener is opened up front so that a bad address is
+// reported before the TUI takes over the terminal.
+func serveMetrics(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for metrics on %s, got err: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", controller.MetricsHandler())
+	go http.Serve(listener, mux)
+
+	return nil
+}
+
+// servePprof starts serving the net/http/pprof endpoints on addr in the
+// background.
+func servePprof(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for pprof on %s, got err: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	go http.Serve(listener, mux)
+
+	return nil
 }
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestResolveNamespace(t *testing.T) {
+	tests := []struct {
+		name             string
+		contextNamespace string
+		fallback         string
+		all              bool
+		want             string
+	}{
+		{"context namespace", "team-a", "default", false, "team-a"},
+		{"empty context namespace", "", "default", false, "default"},
+		{"configured fallback", "", "kube-system", false, "kube-system"},
+		{"all namespaces", "team-a", "default", true, ""},
+		{"all namespaces without a context namespace", "", "default", true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveNamespace(tt.contextNamespace, tt.fallback, tt.all); got != tt.want {
+				t.Errorf("resolveNamespace(%q, %q, %v) = %q, want %q", tt.contextNamespace, tt.fallback, tt.all, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/distribution/reference"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// DeleteDeployment deletes the deployment namespace/name.
+func (c *Controller) DeleteDeployment(namespace, name string) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	err := c.deploymentClient.Deployments(namespace).Delete(context.TODO(), name, meta_v1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete deployment %s/%s, got err: %w", namespace, name, classify(err))
+	}
+	return nil
+}
+
+// GetDeployment fetches the deployment namespace/name from the API server,
+// complete with the fields the caches may have trimmed.
+func (c *Controller) GetDeployment(namespace, name string) (*appsv1.Deployment, error) {
+	deployment, err := c.deploymentClient.Deployments(namespace).Get(context.TODO(), name, meta_v1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment %s/%s, got err: %w", namespace, name, classify(err))
+	}
+	return deployment, nil
+}
+
+// ScaleDeployment sets the desired number of replicas of the deployment
+// namespace/name.
+func (c *Controller) ScaleDeployment(namespace, name string, replicas int32) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	deployments := c.deploymentClient.Deployments(namespace)
+
+	scale, err := deployments.GetScale(context.TODO(), name, meta_v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get scale of deployment %s/%s, got err: %w", namespace, name, classify(err))
+	}
+
+	scale.Spec.Replicas = replicas
+	if _, err := deployments.UpdateScale(context.TODO(), name, scale, meta_v1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to scale deployment %s/%s, got err: %w", namespace, name, classify(err))
+	}
+	return nil
+}
+
+// PreviousReplicasAnnotation records the replicas of a deployment scaled to
+// zero by ScaleToZero, for RestoreReplicas to scale it back to.
+const PreviousReplicasAnnotation = "k8s-tui/previous-replicas"
+
+// ScaleToZero scales the deployment namespace/name to zero replicas,
+// recording how many it had under PreviousReplicasAnnotation.
+func (c *Controller) ScaleToZero(namespace, name string) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	deployments := c.deploymentClient.Deployments(namespace)
+
+	deployment, err := deployments.Get(context.TODO(), name, meta_v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get deployment %s/%s, got err: %w", namespace, name, classify(err))
+	}
+	previous := int32(1)
+	if deployment.Spec.Replicas != nil {
+		previous = *deployment.Spec.Replicas
+	}
+	if previous == 0 {
+		return nil
+	}
+
+	// The update fails with a conflict if the deployment was scaled since
+	// it was read, rather than recording a stale count
+	if deployment.Annotations == nil {
+		deployment.Annotations = make(map[string]string)
+	}
+	deployment.Annotations[PreviousReplicasAnnotation] = strconv.Itoa(int(previous))
+	zero := int32(0)
+	deployment.Spec.Replicas = &zero
+	if _, err := deployments.Update(context.TODO(), deployment, meta_v1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to scale deployment %s/%s to zero, got err: %w", namespace, name, classify(err))
+	}
+	return nil
+}
+
+// RestoreReplicas scales the deployment namespace/name back to replicas,
+// dropping the count recorded by ScaleToZero.
+func (c *Controller) RestoreReplicas(namespace, name string, replicas int32) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	deployments := c.deploymentClient.Deployments(namespace)
+
+	deployment, err := deployments.Get(context.TODO(), name, meta_v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get deployment %s/%s, got err: %w", namespace, name, classify(err))
+	}
+	delete(deployment.Annotations, PreviousReplicasAnnotation)
+	deployment.Spec.Replicas = &replicas
+	if _, err := deployments.Update(context.TODO(), deployment, meta_v1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to restore deployment %s/%s, got err: %w", namespace, name, classify(err))
+	}
+	return nil
+}
+
+// restartedAtAnnotation is the pod template annotation kubectl rollout restart
+// sets to roll out new pods.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// RestartDeployment replaces the pods of the deployment namespace/name with
+// new ones, like kubectl rollout restart.
+func (c *Controller) RestartDeployment(namespace, name string) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{restartedAtAnnotation: time.Now().Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode restart patch, got err: %w", err)
+	}
+
+	_, err = c.deploymentClient.Deployments(namespace).Patch(context.TODO(), name, types.StrategicMergePatchType, patch, meta_v1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to restart deployment %s/%s, got err: %w", namespace, name, classify(err))
+	}
+	return nil
+}
+
+// DecodeDeployment decodes a YAML or JSON deployment manifest.
+func DecodeDeployment(data []byte) (*appsv1.Deployment, error) {
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode manifest, got err: %w", err)
+	}
+	return castObjToDeployment(obj)
+}
+
+// ApplyDeployment creates deployment, or updates it when it already exists.
+// Deployments without a namespace are applied to the default namespace.
+func (c *Controller) ApplyDeployment(deployment *appsv1.Deployment) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	namespace := deployment.GetNamespace()
+	if namespace == "" {
+		namespace = meta_v1.NamespaceDefault
+	}
+	deployments := c.deploymentClient.Deployments(namespace)
+
+	_, err := deployments.Create(context.TODO(), deployment, meta_v1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		_, err = deployments.Update(context.TODO(), deployment, meta_v1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply deployment %s/%s, got err: %w", namespace, deployment.GetName(), classify(err))
+	}
+	return nil
+}
+
+// UpdateDeployment replaces the deployment with the given one, failing with a
+// conflict if it has changed since deployment was read.
+func (c *Controller) UpdateDeployment(deployment *appsv1.Deployment) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	_, err := c.deploymentClient.Deployments(deployment.GetNamespace()).Update(context.TODO(), deployment, meta_v1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update deployment %s/%s, got err: %w", deployment.GetNamespace(), deployment.GetName(), classify(err))
+	}
+	return nil
+}
+
+// PatchLabels adds the labels in add to the deployment namespace/name and
+// removes the label keys in remove, using a strategic merge patch.
+func (c *Controller) PatchLabels(namespace, name string, add map[string]string, remove []string) error {
+	return c.patchMetadata(namespace, name, "labels", add, remove)
+}
+
+// PatchAnnotations adds the annotations in add to the deployment
+// namespace/name and removes the annotation keys in remove.
+func (c *Controller) PatchAnnotations(namespace, name string, add map[string]string, remove []string) error {
+	return c.patchMetadata(namespace, name, "annotations", add, remove)
+}
+
+// patchMetadata patches the given metadata field of the deployment
+// namespace/name, setting removed keys to null so that they are deleted.
+func (c *Controller) patchMetadata(namespace, name, field string, add map[string]string, remove []string) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	values := make(map[string]interface{}, len(add)+len(remove))
+	for k, v := range add {
+		values[k] = v
+	}
+	for _, k := range remove {
+		values[k] = nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{field: values},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s patch, got err: %w", field, err)
+	}
+
+	_, err = c.deploymentClient.Deployments(namespace).Patch(context.TODO(), name, types.StrategicMergePatchType, patch, meta_v1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to patch %s of deployment %s/%s, got err: %w", field, namespace, name, classify(err))
+	}
+	return nil
+}
+
+// SetStrategy replaces the update strategy of the deployment namespace/name.
+// The rolling update parameters are cleared when strategy has none, as the API
+// server rejects them alongside the Recreate type.
+func (c *Controller) SetStrategy(namespace, name string, strategy appsv1.DeploymentStrategy) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	values := map[string]interface{}{"type": strategy.Type, "rollingUpdate": nil}
+	if strategy.RollingUpdate != nil {
+		values["rollingUpdate"] = strategy.RollingUpdate
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"strategy": values},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode strategy patch, got err: %w", err)
+	}
+
+	_, err = c.deploymentClient.Deployments(namespace).Patch(context.TODO(), name, types.StrategicMergePatchType, patch, meta_v1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to set strategy of deployment %s/%s, got err: %w", namespace, name, classify(err))
+	}
+	return nil
+}
+
+// SetImage sets the image of the named container in the pod template of the
+// deployment namespace/name, like kubectl set image.
+func (c *Controller) SetImage(namespace, name, container, image string) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	if _, err := reference.ParseNormalizedNamed(image); err != nil {
+		return fmt.Errorf("invalid image %q, got err: %w", image, err)
+	}
+
+	// Containers are merged by name, leaving the others untouched
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []map[string]string{{"name": container, "image": image}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode image patch, got err: %w", err)
+	}
+
+	_, err = c.deploymentClient.Deployments(namespace).Patch(context.TODO(), name, types.StrategicMergePatchType, patch, meta_v1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to set image of %s in deployment %s/%s, got err: %w", container, namespace, name, classify(err))
+	}
+	return nil
+}
+
+// SetSchedulable cordons the node nodeName when schedulable is false, so that
+// no new pods are scheduled onto it, and uncordons it when it is true, like
+// kubectl cordon and uncordon.
+func (c *Controller) SetSchedulable(nodeName string, schedulable bool) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"unschedulable": !schedulable},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode schedulable patch, got err: %w", err)
+	}
+
+	_, err = c.clientset.CoreV1().Nodes().Patch(context.TODO(), nodeName, types.StrategicMergePatchType, patch, meta_v1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to set node %s schedulable to %t, got err: %w", nodeName, schedulable, classify(err))
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// ServerSideApplyDeployment applies the YAML or JSON deployment manifest with
+// server-side apply, as the configured field manager. Deployments without a
+// namespace are applied to the default namespace. Fields owned by other
+// managers are reported rather than taken over.
+func (c *Controller) ServerSideApplyDeployment(manifest []byte) error {
+	if err := c.checkWritable(); err != nil {
+		return err
+	}
+	deployment, err := DecodeDeployment(manifest)
+	if err != nil {
+		return err
+	}
+	namespace, name := deployment.GetNamespace(), deployment.GetName()
+	if namespace == "" {
+		namespace = meta_v1.NamespaceDefault
+	}
+
+	// The manifest is sent as written, so that only the fields it sets are
+	// owned by the field manager
+	patch, err := yaml.YAMLToJSON(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to convert manifest to JSON, got err: %w", err)
+	}
+
+	_, err = c.deploymentClient.Deployments(namespace).Patch(context.TODO(), name, types.ApplyPatchType, patch, meta_v1.PatchOptions{FieldManager: c.fieldManager})
+	if apierrors.IsConflict(err) {
+		return &classifiedError{class: ErrConflict, err: fmt.Errorf("failed to apply deployment %s/%s, %s", namespace, name, conflictMessage(err))}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply deployment %s/%s, got err: %w", namespace, name, classify(err))
+	}
+	return nil
+}
+
+// conflictMessage lists the fields an apply conflicted on and who owns them.
+func conflictMessage(err error) string {
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil || len(status.Status().Details.Causes) == 0 {
+		return err.Error()
+	}
+
+	var conflicts []string
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == meta_v1.CauseTypeFieldManagerConflict {
+			conflicts = append(conflicts, fmt.Sprintf("%s (%s)", cause.Field, cause.Message))
+		}
+	}
+	if len(conflicts) == 0 {
+		return err.Error()
+	}
+	return "fields owned by other managers: " + strings.Join(conflicts, ", ")
+}
@@ -1,125 +1,500 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"sync"
 	"time"
 
 	"log/slog"
 
 	appsv1 "k8s.io/api/apps/v1"
-	"k8s.io/apimachinery/pkg/api/meta"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
 	v1 "k8s.io/client-go/kubernetes/typed/apps/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
+// errorBufferSize is how many errors are kept for the UI before new ones are
+// dropped.
+const errorBufferSize = 100
+
+// namespaceResolvePeriod is how often the namespace selector is resolved
+// again.
+const namespaceResolvePeriod = time.Minute
+
 type Controller struct {
+	// CurrentDeployments is the live map of the deployments keyed by
+	// namespace/name. The workers write it while the controller runs, so
+	// reading it then is a data race.
+	//
+	// Deprecated: Use Deployments, which returns a snapshot safe to read at
+	// any time.
+	CurrentDeployments map[string]*appsv1.Deployment
+
+	// The cache and informer of the deployments. Both are replaced when the
+	// watched namespace changes, so they must not be read while the
+	// controller runs.
 	Indexer            cache.Indexer
-	Informer           cache.Controller
+	Informer           cache.SharedIndexInformer
 	deploymentClient   v1.AppsV1Interface
 	logger             *slog.Logger
-	queue              workqueue.TypedRateLimitingInterface[string]
-	CurrentDeployments map[string]*appsv1.Deployment
+	healthMutex        sync.Mutex
+	watchErrors        map[string]error       // last watch error of each unhealthy resource
+	listFailures       map[string]listFailure // failed lists of each resource since its last successful one
+	clientset          kubernetes.Interface
+	informerMutex      sync.Mutex
+	namespace          string        // namespace being watched, empty for all
+	informerStop       chan struct{} // stops the running informers, nil until Run
+	fieldSelector      string        // restricts every watch, empty for none
+	changes            chan struct{} // signalled when any store has changed
+	errs               chan error    // errors reported to the UI, see Errors
+	namespaceSelector  string        // label selector of the watched namespaces, empty for all
+	namespaceMutex     sync.RWMutex
+	selectedNamespaces map[string]struct{} // namespaces matching namespaceSelector
+	excludedNamespaces map[string]struct{} // namespaces whose objects are ignored
+	readOnly           bool                // whether the actions changing the cluster are disabled
+	deploymentName     string              // the only deployment watched, empty for all
+	fieldManager       string              // field manager of server-side applies
+	trimObjects        bool                // whether the cached objects are trimmed, see trimObject
+	workers            int                 // number of workers syncing each resource
+	listPageSize       int64               // objects per list page, 0 for client-go's default
+
+	// The client of the metrics API, nil without one, and why the API can't
+	// be used if it can't, see PodMetrics
+	metrics     metricsclient.Interface
+	metricsOnce sync.Once
+	metricsErr  error
+
+	deployments *resourceStore[*appsv1.Deployment]
+	pods        *resourceStore[*corev1.Pod]
+	nodes       *resourceStore[*corev1.Node]
+	cronJobs    *resourceStore[*batchv1.CronJob]
+	jobs        *resourceStore[*batchv1.Job]
+	ingresses   *resourceStore[*networkingv1.Ingress]
+	hpas        *resourceStore[*autoscalingv2.HorizontalPodAutoscaler]
+	replicaSets *resourceStore[*appsv1.ReplicaSet]
+	services    *resourceStore[*corev1.Service]
+	slices      *resourceStore[*discoveryv1.EndpointSlice]
+	quotas      *resourceStore[*corev1.ResourceQuota]
+	// The custom resource, nil unless set with WithCustomResource
+	custom    *resourceStore[*unstructured.Unstructured]
+	customGVR schema.GroupVersionResource
+	stores    []store // every store above
 }
 
 // NewController creates a new Controller.
-func NewController(coreClient v1.AppsV1Interface) *Controller {
+func NewController(clientset kubernetes.Interface, opts ...Option) *Controller {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	c := &Controller{
+		deploymentClient:  clientset.AppsV1(),
+		logger:            slog.New(slog.NewJSONHandler(o.logOutput, &slog.HandlerOptions{Level: o.logLevel})),
+		watchErrors:       make(map[string]error),
+		listFailures:      make(map[string]listFailure),
+		clientset:         clientset,
+		fieldSelector:     o.fieldSelector,
+		changes:           make(chan struct{}, 1),
+		errs:              make(chan error, errorBufferSize),
+		namespaceSelector: o.namespaceSelector,
+		readOnly:          o.readOnly,
+		namespace:         o.namespace,
+		deploymentName:    o.deploymentName,
+		fieldManager:      o.fieldManager,
+		trimObjects:       o.trimObjects,
+		workers:           o.workers,
+		listPageSize:      o.listPageSize,
+		metrics:           o.metrics,
+	}
+
+	c.excludedNamespaces = make(map[string]struct{}, len(o.excludedNamespaces))
+	for _, namespace := range o.excludedNamespaces {
+		c.excludedNamespaces[namespace] = struct{}{}
+	}
+
+	c.deployments = newResourceStore[*appsv1.Deployment]("deployments", &appsv1.Deployment{}, o.newRateLimiter, listWatch(func(namespace string) typedClient[*appsv1.DeploymentList] {
+		return clientset.AppsV1().Deployments(namespace)
+	}, c.deploymentOptions))
+	c.deployments.syncFunc = c.syncDeployment
+	c.CurrentDeployments = c.deployments.objects
+
+	c.pods = newResourceStore[*corev1.Pod]("pods", &corev1.Pod{}, o.newRateLimiter, listWatch(func(namespace string) typedClient[*corev1.PodList] {
+		return clientset.CoreV1().Pods(namespace)
+	}, c.filterOptions))
+	// Nodes are cluster scoped so ignore the namespace
+	c.nodes = newResourceStore[*corev1.Node]("nodes", &corev1.Node{}, o.newRateLimiter, listWatch(func(string) typedClient[*corev1.NodeList] {
+		return clientset.CoreV1().Nodes()
+	}, c.filterOptions))
+	c.cronJobs = newResourceStore[*batchv1.CronJob]("cronjobs", &batchv1.CronJob{}, o.newRateLimiter, listWatch(func(namespace string) typedClient[*batchv1.CronJobList] {
+		return clientset.BatchV1().CronJobs(namespace)
+	}, c.filterOptions))
+	c.jobs = newResourceStore[*batchv1.Job]("jobs", &batchv1.Job{}, o.newRateLimiter, listWatch(func(namespace string) typedClient[*batchv1.JobList] {
+		return clientset.BatchV1().Jobs(namespace)
+	}, c.filterOptions))
+	c.ingresses = newResourceStore[*networkingv1.Ingress]("ingresses", &networkingv1.Ingress{}, o.newRateLimiter, listWatch(func(namespace string) typedClient[*networkingv1.IngressList] {
+		return clientset.NetworkingV1().Ingresses(namespace)
+	}, c.filterOptions))
+	c.hpas = newResourceStore[*autoscalingv2.HorizontalPodAutoscaler]("horizontalpodautoscalers", &autoscalingv2.HorizontalPodAutoscaler{}, o.newRateLimiter, listWatch(func(namespace string) typedClient[*autoscalingv2.HorizontalPodAutoscalerList] {
+		return clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace)
+	}, c.filterOptions))
+	c.replicaSets = newResourceStore[*appsv1.ReplicaSet]("replicasets", &appsv1.ReplicaSet{}, o.newRateLimiter, listWatch(func(namespace string) typedClient[*appsv1.ReplicaSetList] {
+		return clientset.AppsV1().ReplicaSets(namespace)
+	}, c.filterOptions))
+	c.services = newResourceStore[*corev1.Service]("services", &corev1.Service{}, o.newRateLimiter, listWatch(func(namespace string) typedClient[*corev1.ServiceList] {
+		return clientset.CoreV1().Services(namespace)
+	}, c.filterOptions))
+	c.slices = newResourceStore[*discoveryv1.EndpointSlice]("endpointslices", &discoveryv1.EndpointSlice{}, o.newRateLimiter, listWatch(func(namespace string) typedClient[*discoveryv1.EndpointSliceList] {
+		return clientset.DiscoveryV1().EndpointSlices(namespace)
+	}, c.filterOptions))
+
+	c.quotas = newResourceStore[*corev1.ResourceQuota]("resourcequotas", &corev1.ResourceQuota{}, o.newRateLimiter, listWatch(func(namespace string) typedClient[*corev1.ResourceQuotaList] {
+		return clientset.CoreV1().ResourceQuotas(namespace)
+	}, c.filterOptions))
+
+	c.stores = []store{c.deployments, c.pods, c.nodes, c.cronJobs, c.jobs, c.ingresses, c.hpas, c.replicaSets, c.services, c.slices, c.quotas}
+	if o.custom != nil {
+		c.customGVR = o.custom.gvr
+		c.custom = newResourceStore[*unstructured.Unstructured](o.custom.gvr.Resource, &unstructured.Unstructured{}, o.newRateLimiter, c.dynamicListWatch(o.custom))
+		c.stores = append(c.stores, c.custom)
+	}
+	c.buildInformers()
+
+	return c
+}
+
+// typedClient is the part of a typed client, such as
+// CoreV1().Pods(namespace), the informers list and watch with.
+type typedClient[L runtime.Object] interface {
+	List(ctx context.Context, opts meta_v1.ListOptions) (L, error)
+	Watch(ctx context.Context, opts meta_v1.ListOptions) (watch.Interface, error)
+}
+
+// listWatch returns the list watch factory of the resource served by the
+// typed client returned by client for a namespace. modify is applied to the
+// options of every list and watch call.
+func listWatch[L runtime.Object](client func(namespace string) typedClient[L], modify func(options *meta_v1.ListOptions)) func(namespace string) *cache.ListWatch {
+	return func(namespace string) *cache.ListWatch {
+		return &cache.ListWatch{
+			ListFunc: func(options meta_v1.ListOptions) (runtime.Object, error) {
+				modify(&options)
+				return client(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options meta_v1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				modify(&options)
+				return client(namespace).Watch(context.TODO(), options)
+			},
+		}
+	}
+}
+
+// deploymentOptions applies filterOptions along with the name of the only
+// deployment watched, if set with WithDeploymentName.
+func (c *Controller) deploymentOptions(options *meta_v1.ListOptions) {
+	c.filterOptions(options)
+	if c.deploymentName == "" {
+		return
+	}
+	// Field selector terms separated by commas must all match
+	selector := fields.OneTermEqualSelector("metadata.name", c.deploymentName).String()
+	if options.FieldSelector != "" {
+		selector = options.FieldSelector + "," + selector
+	}
+	options.FieldSelector = selector
+}
+
+// filterOptions applies the configured field selector to the options of a
+// list or watch call, and the page size to those of a list call.
+func (c *Controller) filterOptions(options *meta_v1.ListOptions) {
+	options.FieldSelector = c.fieldSelector
+	if c.listPageSize > 0 && !options.Watch {
+		options.Limit = c.listPageSize
+	}
+}
+
+// buildInformers creates the informers watching c.namespace, replacing any
+// previous ones. The caller must hold informerMutex or be the constructor.
+func (c *Controller) buildInformers() {
+	for _, s := range c.stores {
+		s.build(c)
+	}
+	c.Informer = c.deployments.getInformer()
+	c.Indexer = c.Informer.GetIndexer()
+}
+
+// startInformers runs the current informers until they are replaced or the
+// controller stops. The caller must hold informerMutex.
+func (c *Controller) startInformers() {
+	c.informerStop = make(chan struct{})
+	for _, s := range c.stores {
+		go s.run(c.informerStop)
+	}
+}
 
-	// Create a deployment watcher
-	deploymentsListWatcher := cache.NewFilteredListWatchFromClient(coreClient.RESTClient(), "deployments", "", func(options *meta_v1.ListOptions) {})
+// stopInformers stops the running informers.
+func (c *Controller) stopInformers() {
+	c.informerMutex.Lock()
+	defer c.informerMutex.Unlock()
+	close(c.informerStop)
+}
 
-	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
-	indexer, informer := cache.NewIndexerInformer(deploymentsListWatcher, &appsv1.Deployment{}, 0, cache.ResourceEventHandlerFuncs{
+// Deployments returns a snapshot of the current deployments keyed by
+// namespace/name.
+func (c *Controller) Deployments() map[string]*appsv1.Deployment {
+	return c.deployments.snapshot()
+}
+
+// Pods returns a snapshot of the current pods keyed by namespace/name.
+func (c *Controller) Pods() map[string]*corev1.Pod {
+	return c.pods.snapshot()
+}
+
+// Nodes returns a snapshot of the current nodes keyed by name.
+func (c *Controller) Nodes() map[string]*corev1.Node {
+	return c.nodes.snapshot()
+}
+
+// CronJobs returns a snapshot of the current cron jobs keyed by
+// namespace/name.
+func (c *Controller) CronJobs() map[string]*batchv1.CronJob {
+	return c.cronJobs.snapshot()
+}
+
+// Jobs returns a snapshot of the current jobs keyed by namespace/name.
+func (c *Controller) Jobs() map[string]*batchv1.Job {
+	return c.jobs.snapshot()
+}
+
+// Ingresses returns a snapshot of the current ingresses keyed by
+// namespace/name.
+func (c *Controller) Ingresses() map[string]*networkingv1.Ingress {
+	return c.ingresses.snapshot()
+}
+
+// HPAs returns a snapshot of the current horizontal pod autoscalers keyed by
+// namespace/name.
+func (c *Controller) HPAs() map[string]*autoscalingv2.HorizontalPodAutoscaler {
+	return c.hpas.snapshot()
+}
+
+// ReplicaSets returns a snapshot of the current replica sets keyed by
+// namespace/name.
+func (c *Controller) ReplicaSets() map[string]*appsv1.ReplicaSet {
+	return c.replicaSets.snapshot()
+}
+
+// Services returns a snapshot of the current services keyed by
+// namespace/name.
+func (c *Controller) Services() map[string]*corev1.Service {
+	return c.services.snapshot()
+}
+
+// EndpointSlices returns a snapshot of the current endpoint slices keyed by
+// namespace/name.
+func (c *Controller) EndpointSlices() map[string]*discoveryv1.EndpointSlice {
+	return c.slices.snapshot()
+}
+
+// ResourceQuotas returns a snapshot of the current resource quotas keyed by
+// namespace/name.
+func (c *Controller) ResourceQuotas() map[string]*corev1.ResourceQuota {
+	return c.quotas.snapshot()
+}
+
+// newInformer creates an informer which feeds the keys of changed objects
+// into queue and keeps track of the health of its watch.
+func (c *Controller) newInformer(resource string, lw *cache.ListWatch, objType runtime.Object, queue workqueue.TypedRateLimitingInterface[string]) cache.SharedIndexInformer {
+	informer := cache.NewSharedIndexInformer(c.trackHealth(resource, lw), objType, 0, cache.Indexers{})
+	informer.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: c.inSelectedNamespaces,
+		Handler:    newQueueingHandler(resource, queue),
+	})
+	// These can only fail once the informer has started
+	_ = informer.SetWatchErrorHandler(c.watchErrorHandler(resource))
+	if c.trimObjects {
+		_ = informer.SetTransform(trimObject)
+	}
+	return informer
+}
+
+// newQueueingHandler returns event handlers which add the key of every
+// added, updated or deleted object to the given queue.
+func newQueueingHandler(resource string, queue workqueue.TypedRateLimitingInterface[string]) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
+			informerEventsTotal.WithLabelValues(resource, "add").Inc()
 			key, err := cache.MetaNamespaceKeyFunc(obj)
 			if err == nil {
 				queue.Add(key)
 			}
 		},
 		UpdateFunc: func(old interface{}, new interface{}) {
+			informerEventsTotal.WithLabelValues(resource, "update").Inc()
 			key, err := cache.MetaNamespaceKeyFunc(new)
 			if err == nil {
 				queue.Add(key)
 			}
 		},
 		DeleteFunc: func(obj interface{}) {
+			informerEventsTotal.WithLabelValues(resource, "delete").Inc()
 			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 			if err == nil {
 				queue.Add(key)
 			}
 		},
-	}, cache.Indexers{})
+	}
+}
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+// HasSynced returns true once every informer has completed its initial list,
+// or has been denied access to its resource so that it never will.
+func (c *Controller) HasSynced() bool {
+	c.informerMutex.Lock()
+	defer c.informerMutex.Unlock()
+	for _, s := range c.stores {
+		if !s.hasSynced() && !c.Denied(s.name()) {
+			return false
+		}
+	}
+	return true
+}
 
-	return &Controller{
-		Informer:           informer,
-		Indexer:            indexer,
-		queue:              queue,
-		deploymentClient:   coreClient,
-		logger:             logger,
-		CurrentDeployments: make(map[string]*appsv1.Deployment),
+// QueueDepth returns the number of keys waiting to be synced across every
+// resource. A depth which stays high means the snapshots lag behind the
+// cluster.
+func (c *Controller) QueueDepth() int {
+	depth := 0
+	for _, s := range c.stores {
+		depth += s.queueLen()
+	}
+	return depth
+}
+
+// Changes returns a channel which receives a value after the snapshots have
+// changed. Changes made in quick succession are coalesced into one.
+func (c *Controller) Changes() <-chan struct{} {
+	return c.changes
+}
+
+// notifyChange signals Changes without blocking, a pending signal already
+// covers this change.
+func (c *Controller) notifyChange() {
+	select {
+	case c.changes <- struct{}{}:
+	default:
+	}
+}
+
+// Errors returns a channel receiving the sync and watch errors of the
+// controller. Errors are dropped rather than blocking while nobody reads them.
+func (c *Controller) Errors() <-chan error {
+	return c.errs
+}
+
+// reportError passes err to Errors without blocking.
+func (c *Controller) reportError(err error) {
+	select {
+	case c.errs <- err:
+	default:
 	}
 }
 
 // Run begins watching and syncing.
 func (c *Controller) Run(stopCh chan struct{}) {
-	defer runtime.HandleCrash()
+	defer utilruntime.HandleCrash()
 
 	// Let the workers stop when we are done
-	defer c.queue.ShutDown()
+	for _, s := range c.stores {
+		defer s.shutDown()
+	}
+
+	if c.namespaceSelector != "" {
+		c.resolveNamespaces()
+	}
+
+	c.informerMutex.Lock()
+	c.startInformers()
+	c.informerMutex.Unlock()
+	defer c.stopInformers()
 
-	go c.Informer.Run(stopCh)
+	// Pick up namespaces which have been labelled or unlabelled since
+	if c.namespaceSelector != "" {
+		go wait.Until(c.resolveNamespaces, namespaceResolvePeriod, stopCh)
+	}
 
 	// Wait for all involved caches to be synced, before processing items from the queue is started
-	if !cache.WaitForCacheSync(stopCh, c.Informer.HasSynced) {
-		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
+	if !cache.WaitForCacheSync(stopCh, c.HasSynced) {
+		utilruntime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
 		return
 	}
 
-	go wait.Until(c.RunWorker, time.Second, stopCh)
+	// The queues hand a key to one worker at a time, so several workers
+	// never sync the same object at once
+	for _, s := range c.stores {
+		for i := 0; i < c.workers; i++ {
+			go wait.Until(func() { s.work(c) }, time.Second, stopCh)
+		}
+	}
 
 	<-stopCh
 }
 
+// RunWorker processes the deployments queue until it is shut down.
 func (c *Controller) RunWorker() {
-	for c.processNextItem() {
-	}
+	c.deployments.work(c)
 }
 
-func (c *Controller) processNextItem() bool {
+func (c *Controller) processNextItem(resource string, queue workqueue.TypedRateLimitingInterface[string], sync func(key string) error) bool {
 	// Wait until there is a new item in the working queue
-	key, quit := c.queue.Get()
+	key, quit := queue.Get()
 	if quit {
 		return false
 	}
 	// Tell the queue that we are done with processing this key. This unblocks the key for other workers
-	// This allows safe parallel processing because two deployments with the same key are never processed in
+	// This allows safe parallel processing because two objects with the same key are never processed in
 	// parallel.
-	defer c.queue.Done(key)
+	defer queue.Done(key)
 
 	// Invoke the method containing the business logic
-	err := c.syncDeployment(key)
+	err := sync(key)
+	if err == nil {
+		c.notifyChange()
+	}
 	// Handle the error if something went wrong during the execution of the business logic
-	c.handleErr(err, key)
+	c.handleErr(resource, queue, err, key)
 	return true
 }
 
 // syncDeployment is the business logic of the controller. The retry logic should
 // not be part of the business logic.
 func (c *Controller) syncDeployment(key string) error {
-	obj, exists, err := c.Indexer.GetByKey(key)
+	// Indexer is replaced along with the informers, read the current one
+	// under the lock of the store
+	obj, exists, err := c.deployments.getInformer().GetIndexer().GetByKey(key)
 	if err != nil {
-		// c.logger.Error("Fetching object from store failed", "key", key, "err", err)
+		c.logger.Error("Fetching object from store failed", "key", key, "err", err)
 		return err
 	}
 
 	if !exists {
-		// c.logger.Error("deployment does not exist anymore", "key", key)
+		c.logger.Debug("deployment does not exist anymore", "key", key)
 		return c.deleteDeplotment(key)
 	}
 
@@ -129,55 +504,58 @@ func (c *Controller) syncDeployment(key string) error {
 	}
 
 	// TODO Business Logic
-	c.CurrentDeployments[changedDeployment.GetNamespace()+"/"+changedDeployment.GetName()] = changedDeployment
+	c.logger.Debug("syncing deployment", "key", key, "resourceVersion", changedDeployment.GetResourceVersion())
+	c.deployments.set(changedDeployment.GetNamespace()+"/"+changedDeployment.GetName(), changedDeployment)
 
 	return nil
 }
 
 // handleErr checks if an error happened and makes sure we will retry later.
-func (c *Controller) handleErr(err error, key string) {
+func (c *Controller) handleErr(resource string, queue workqueue.TypedRateLimitingInterface[string], err error, key string) {
 	if err == nil {
 		// Forget about the AddRateLimited history of the key on every successful synchronization.
 		// This ensures that future processing of updates for this key is not delayed because of
 		// an outdated error history.
-		c.queue.Forget(key)
+		queue.Forget(key)
 		return
 	}
 
-	// c.logger.Error("got error", "error", err)
+	syncErrorsTotal.WithLabelValues(resource).Inc()
+
+	// An object of the wrong type stays wrong however often it is retried
+	if errors.Is(err, errUnexpectedType) {
+		queue.Forget(key)
+		utilruntime.HandleError(err)
+		c.reportError(fmt.Errorf("dropped %s %s, got err: %w", resource, key, err))
+		c.logger.Warn("Dropping object of unexpected type", "resource", resource, "key", key, "error", err)
+		return
+	}
 
 	// This controller retries 5 times if something goes wrong. After that, it stops trying.
-	if c.queue.NumRequeues(key) < 5 {
-		// c.logger.Info("Error syncing deployment", "deployment", key, "error", err)
+	if queue.NumRequeues(key) < 5 {
+		c.logger.Info("Error syncing", "resource", resource, "key", key, "error", err)
 
 		// Re-enqueue the key rate limited. Based on the rate limiter on the
 		// queue and the re-enqueue history, the key will be processed later again.
-		c.queue.AddRateLimited(key)
+		queue.AddRateLimited(key)
 		return
 	}
 
-	c.queue.Forget(key)
+	queue.Forget(key)
 	// Report to an external entity that, even after several retries, we could not successfully process this key
-	runtime.HandleError(err)
-	// c.logger.Info("Dropping deployment out of queue", "deployment", key, "error", err)
+	utilruntime.HandleError(err)
+	c.reportError(fmt.Errorf("gave up syncing %s %s, got err: %w", resource, key, err))
+	c.logger.Warn("Dropping out of queue", "resource", resource, "key", key, "error", err)
 }
 
 func (c *Controller) deleteDeplotment(key string) error {
 
 	// TODO: Business logic here
-	delete(c.CurrentDeployments, key)
+	c.deployments.delete(key)
 
 	return nil
 }
 
 func castObjToDeployment(obj interface{}) (*appsv1.Deployment, error) {
-	s, ok := obj.(*appsv1.Deployment)
-	if !ok {
-		accessor, err := meta.Accessor(obj)
-		if err != nil {
-			return nil, fmt.Errorf("could not cast obj to deployment, failed to create accessor, got err: %w", err)
-		}
-		return nil, fmt.Errorf("could not cast obj %s/%s (uid: %s) to deployment", accessor.GetNamespace(), accessor.GetName(), accessor.GetUID())
-	}
-	return s, nil
+	return castObj[*appsv1.Deployment](obj, &appsv1.Deployment{})
 }
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// newTestController creates a controller watching an empty fake clientset,
+// without running it.
+func newTestController(t *testing.T, opts ...Option) *Controller {
+	t.Helper()
+	return NewController(fake.NewSimpleClientset(), append([]Option{WithLogOutput(io.Discard)}, opts...)...)
+}
+
+// newTestDeployment returns a deployment called name in namespace.
+func newTestDeployment(namespace, name string) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: meta_v1.ObjectMeta{Namespace: namespace, Name: name}}
+}
+
+// addToDeploymentCache adds objs to the cache syncDeployment reads.
+func addToDeploymentCache(t *testing.T, c *Controller, objs ...interface{}) {
+	t.Helper()
+	indexer := c.deployments.getInformer().GetIndexer()
+	for _, obj := range objs {
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("Add() failed, got err: %v", err)
+		}
+	}
+}
+
+func TestSyncDeploymentAddsCachedDeployments(t *testing.T) {
+	c := newTestController(t)
+	web := newTestDeployment("default", "web")
+	addToDeploymentCache(t, c, web, newTestDeployment("default", "api"))
+
+	if err := c.syncDeployment("default/web"); err != nil {
+		t.Fatalf("syncDeployment() failed, got err: %v", err)
+	}
+	if got := c.Deployments(); len(got) != 1 || got["default/web"] != web {
+		t.Errorf("Deployments() = %v, want only default/web", got)
+	}
+	if got := c.CurrentDeployments["default/web"]; got != web {
+		t.Errorf("CurrentDeployments[default/web] = %v, want the deployment", got)
+	}
+
+	// A newer version replaces the old one
+	updated := newTestDeployment("default", "web")
+	updated.ResourceVersion = "2"
+	addToDeploymentCache(t, c, updated)
+	if err := c.syncDeployment("default/web"); err != nil {
+		t.Fatalf("syncDeployment() failed, got err: %v", err)
+	}
+	if got := c.Deployments()["default/web"]; got != updated {
+		t.Errorf("Deployments()[default/web] = %v, want the updated deployment", got)
+	}
+}
+
+func TestSyncDeploymentPrunesMissingDeployments(t *testing.T) {
+	c := newTestController(t)
+	c.deployments.set("default/web", newTestDeployment("default", "web"))
+	c.deployments.set("default/api", newTestDeployment("default", "api"))
+
+	// Neither is in the cache any more
+	if err := c.syncDeployment("default/web"); err != nil {
+		t.Fatalf("syncDeployment() failed, got err: %v", err)
+	}
+	if got := c.Deployments(); len(got) != 1 || got["default/api"] == nil {
+		t.Errorf("Deployments() = %v, want only default/api", got)
+	}
+	if _, ok := c.CurrentDeployments["default/web"]; ok {
+		t.Error("CurrentDeployments still holds default/web")
+	}
+
+	if err := c.deleteDeplotment("default/api"); err != nil {
+		t.Fatalf("deleteDeplotment() failed, got err: %v", err)
+	}
+	if got := c.Deployments(); len(got) != 0 {
+		t.Errorf("Deployments() = %v, want none", got)
+	}
+
+	// Deleting a deployment which was never synced is fine too
+	if err := c.deleteDeplotment("default/missing"); err != nil {
+		t.Errorf("deleteDeplotment() of a missing deployment failed, got err: %v", err)
+	}
+}
+
+func TestSyncDeploymentRejectsOtherObjects(t *testing.T) {
+	c := newTestController(t)
+	pod := &corev1.Pod{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "web", UID: "1234"}}
+	addToDeploymentCache(t, c, pod)
+
+	err := c.syncDeployment("default/web")
+	if !errors.Is(err, errUnexpectedType) {
+		t.Fatalf("syncDeployment() = %v, want an errUnexpectedType", err)
+	}
+	_, castErr := castObjToDeployment(pod)
+	if castErr == nil || err.Error() != castErr.Error() {
+		t.Errorf("syncDeployment() = %q, want the error of castObjToDeployment %q", err, castErr)
+	}
+	if got := c.Deployments(); len(got) != 0 {
+		t.Errorf("Deployments() = %v, want none", got)
+	}
+}
+
+// newTestQueue returns a queue retrying straight away.
+func newTestQueue() workqueue.TypedRateLimitingInterface[string] {
+	return workqueue.NewTypedRateLimitingQueue(workqueue.NewTypedItemExponentialFailureRateLimiter[string](0, time.Millisecond))
+}
+
+func TestHandleErrForgetsCastFailures(t *testing.T) {
+	c := newTestController(t)
+	queue := newTestQueue()
+	defer queue.ShutDown()
+
+	// The key has failed before for another reason
+	queue.AddRateLimited("default/web")
+	key, _ := queue.Get()
+	queue.Done(key)
+
+	_, err := castObjToDeployment(&corev1.Pod{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "web"}})
+	c.handleErr("deployments", queue, err, "default/web")
+	if n := queue.NumRequeues("default/web"); n != 0 {
+		t.Errorf("NumRequeues() = %d after a cast failure, want 0", n)
+	}
+	if n := queue.Len(); n != 0 {
+		t.Errorf("Len() = %d after a cast failure, want 0", n)
+	}
+	select {
+	case err := <-c.Errors():
+		if !errors.Is(err, errUnexpectedType) {
+			t.Errorf("Errors() received %v, want an errUnexpectedType", err)
+		}
+	default:
+		t.Error("the dropped object wasn't reported on Errors()")
+	}
+}
+
+func TestHandleErrRetriesOtherErrors(t *testing.T) {
+	c := newTestController(t)
+	queue := newTestQueue()
+	defer queue.ShutDown()
+
+	c.handleErr("deployments", queue, errors.New("conflict"), "default/web")
+	if n := queue.NumRequeues("default/web"); n != 1 {
+		t.Errorf("NumRequeues() = %d after a failed sync, want 1", n)
+	}
+
+	// Until the retries run out
+	for range 5 {
+		c.handleErr("deployments", queue, errors.New("conflict"), "default/web")
+	}
+	if n := queue.NumRequeues("default/web"); n != 0 {
+		t.Errorf("NumRequeues() = %d after giving up, want 0", n)
+	}
+
+	c.handleErr("deployments", queue, nil, "default/api")
+	if n := queue.NumRequeues("default/api"); n != 0 {
+		t.Errorf("NumRequeues() = %d after a successful sync, want 0", n)
+	}
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+)
+
+// customResource is the resource of arbitrary type watched through the
+// dynamic client, see WithCustomResource.
+type customResource struct {
+	client     dynamic.Interface
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+// dynamicListWatch returns the list watch factory of the custom resource,
+// ignoring the namespace when it is cluster scoped.
+func (c *Controller) dynamicListWatch(custom *customResource) func(namespace string) *cache.ListWatch {
+	return func(namespace string) *cache.ListWatch {
+		if !custom.namespaced {
+			namespace = meta_v1.NamespaceAll
+		}
+		client := custom.client.Resource(custom.gvr).Namespace(namespace)
+		return &cache.ListWatch{
+			ListFunc: func(options meta_v1.ListOptions) (runtime.Object, error) {
+				c.filterOptions(&options)
+				return client.List(context.TODO(), options)
+			},
+			WatchFunc: func(options meta_v1.ListOptions) (watch.Interface, error) {
+				options.Watch = true
+				c.filterOptions(&options)
+				return client.Watch(context.TODO(), options)
+			},
+		}
+	}
+}
+
+// CustomResource returns the custom resource set by WithCustomResource, and
+// false when there is none.
+func (c *Controller) CustomResource() (schema.GroupVersionResource, bool) {
+	if c.custom == nil {
+		return schema.GroupVersionResource{}, false
+	}
+	return c.customGVR, true
+}
+
+// CustomResources returns a snapshot of the current objects of the custom
+// resource keyed by namespace/name, or by name when it is cluster scoped. It
+// is nil without a custom resource.
+func (c *Controller) CustomResources() map[string]*unstructured.Unstructured {
+	if c.custom == nil {
+		return nil
+	}
+	return c.custom.snapshot()
+}
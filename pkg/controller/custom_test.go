@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+)
+
+// recordingClient records the options of the list and watch calls made
+// through it, as a typed and as a dynamic client. The other methods aren't
+// implemented.
+type recordingClient struct {
+	dynamic.Interface
+	dynamic.NamespaceableResourceInterface
+	lists, watches []meta_v1.ListOptions
+}
+
+func (r *recordingClient) Resource(schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return r
+}
+
+func (r *recordingClient) Namespace(string) dynamic.ResourceInterface {
+	return r
+}
+
+func (r *recordingClient) List(_ context.Context, options meta_v1.ListOptions) (*unstructured.UnstructuredList, error) {
+	r.lists = append(r.lists, options)
+	return &unstructured.UnstructuredList{}, nil
+}
+
+func (r *recordingClient) Watch(_ context.Context, options meta_v1.ListOptions) (watch.Interface, error) {
+	r.watches = append(r.watches, options)
+	return watch.NewFake(), nil
+}
+
+// typedRecordingClient is recordingClient as a typed deployment client.
+type typedRecordingClient struct {
+	*recordingClient
+}
+
+func (r typedRecordingClient) List(ctx context.Context, options meta_v1.ListOptions) (*appsv1.DeploymentList, error) {
+	_, err := r.recordingClient.List(ctx, options)
+	return &appsv1.DeploymentList{}, err
+}
+
+func TestListPageSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		listWatch func(c *Controller, client *recordingClient) *cache.ListWatch
+	}{
+		{
+			name: "typed",
+			listWatch: func(c *Controller, client *recordingClient) *cache.ListWatch {
+				typed := func(string) typedClient[*appsv1.DeploymentList] { return typedRecordingClient{client} }
+				return listWatch(typed, c.filterOptions)("default")
+			},
+		},
+		{
+			name: "custom resource",
+			listWatch: func(c *Controller, client *recordingClient) *cache.ListWatch {
+				return c.dynamicListWatch(&customResource{client: client, namespaced: true})("default")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestController(t, WithListPageSize(50), WithFieldSelector("status.phase=Running"))
+			client := &recordingClient{}
+			lw := tt.listWatch(c, client)
+
+			if _, err := lw.List(meta_v1.ListOptions{}); err != nil {
+				t.Fatalf("List() failed, got err: %v", err)
+			}
+			w, err := lw.Watch(meta_v1.ListOptions{ResourceVersion: "1"})
+			if err != nil {
+				t.Fatalf("Watch() failed, got err: %v", err)
+			}
+			w.Stop()
+
+			if got := client.lists[0]; got.Limit != 50 || got.FieldSelector != "status.phase=Running" {
+				t.Errorf("list options = %+v, want a limit of 50 and the field selector", got)
+			}
+			// A watch streams changes, it has no pages
+			if got := client.watches[0]; got.Limit != 0 || !got.Watch || got.FieldSelector != "status.phase=Running" {
+				t.Errorf("watch options = %+v, want no limit and the field selector", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// The classes of the API errors returned by the controller, for callers to
+// tell apart with errors.Is. The errors keep the message of the API server
+// and can still be inspected with the apierrors helpers.
+var (
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// classifiedError is an API error along with its class.
+type classifiedError struct {
+	class error
+	err   error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.class, e.err}
+}
+
+// classify wraps the API error err with its class, returning any other error
+// as it is.
+func classify(err error) error {
+	var class error
+	switch {
+	case apierrors.IsForbidden(err):
+		class = ErrForbidden
+	case apierrors.IsNotFound(err):
+		class = ErrNotFound
+	case apierrors.IsConflict(err):
+		class = ErrConflict
+	case apierrors.IsUnauthorized(err):
+		class = ErrUnauthorized
+	default:
+		return err
+	}
+	return &classifiedError{class: class, err: err}
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClassify(t *testing.T) {
+	deployments := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	sentinels := []error{ErrForbidden, ErrNotFound, ErrConflict, ErrUnauthorized}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"forbidden", apierrors.NewForbidden(deployments, "web", errors.New("no RBAC")), ErrForbidden},
+		{"not found", apierrors.NewNotFound(deployments, "web"), ErrNotFound},
+		{"conflict", apierrors.NewConflict(deployments, "web", errors.New("modified")), ErrConflict},
+		{"unauthorized", apierrors.NewUnauthorized("expired token"), ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classify(tt.err)
+			for _, sentinel := range sentinels {
+				if is := errors.Is(got, sentinel); is != (sentinel == tt.want) {
+					t.Errorf("errors.Is(classify(err), %v) = %v, want %v", sentinel, is, !is)
+				}
+			}
+			// The API error is kept as it is
+			if got.Error() != tt.err.Error() {
+				t.Errorf("classify(err).Error() = %q, want %q", got.Error(), tt.err.Error())
+			}
+			if !errors.Is(got, tt.err) {
+				t.Error("classify(err) doesn't wrap err")
+			}
+			var status apierrors.APIStatus
+			if !errors.As(got, &status) || status.Status().Code != tt.err.(apierrors.APIStatus).Status().Code {
+				t.Error("classify(err) hides the status of err")
+			}
+			// Wrapping again keeps the class
+			if wrapped := fmt.Errorf("failed to scale deployment, got err: %w", got); !errors.Is(wrapped, tt.want) {
+				t.Errorf("errors.Is(wrapped, %v) = false, want true", tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyPassesOtherErrorsThrough(t *testing.T) {
+	for _, err := range []error{
+		nil,
+		errors.New("connection refused"),
+		apierrors.NewBadRequest("invalid replicas"),
+		apierrors.NewInternalError(errors.New("etcd unavailable")),
+	} {
+		if got := classify(err); got != err {
+			t.Errorf("classify(%v) = %v, want it unchanged", err, got)
+		}
+	}
+}
+
+func TestActionsClassifyErrors(t *testing.T) {
+	c := newTestController(t)
+	if err := c.DeleteDeployment("default", "web"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DeleteDeployment() of a missing deployment = %v, want an ErrNotFound", err)
+	}
+	if err := c.ScaleDeployment("default", "web", 3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ScaleDeployment() of a missing deployment = %v, want an ErrNotFound", err)
+	}
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Healthy returns false while the watch of any resource is failing.
+func (c *Controller) Healthy() bool {
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+	return len(c.watchErrors) == 0
+}
+
+// Denied reports whether the watch of resource, e.g. pods, is forbidden. The
+// other resources are watched regardless.
+func (c *Controller) Denied(resource string) bool {
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+	return apierrors.IsForbidden(c.watchErrors[resource])
+}
+
+// Err returns the watch errors which retrying won't fix, such as missing
+// credentials or an unsupported field selector, or nil when there are none.
+// Missing permissions only count once every resource is denied, access to
+// some of them being enough to carry on. Wrappers can use it
+// to report the controller ended up in an unrecoverable state.
+func (c *Controller) Err() error {
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+
+	denied := 0
+	for _, err := range c.watchErrors {
+		if apierrors.IsForbidden(err) {
+			denied++
+		}
+	}
+	allDenied := denied == len(c.stores)
+
+	resources := make([]string, 0, len(c.watchErrors))
+	for resource := range c.watchErrors {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+
+	var errs []error
+	for _, resource := range resources {
+		err := c.watchErrors[resource]
+		if apierrors.IsUnauthorized(err) || apierrors.IsBadRequest(err) || (allDenied && apierrors.IsForbidden(err)) {
+			errs = append(errs, fmt.Errorf("failed to watch %s, got err: %w", resource, classify(err)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Controller) setWatchError(resource string, err error) {
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+	if err == nil {
+		delete(c.watchErrors, resource)
+	} else {
+		c.watchErrors[resource] = err
+	}
+}
+
+// listFailure counts the failed lists of a resource since its last
+// successful one.
+type listFailure struct {
+	attempts int
+	err      error // the last error
+}
+
+// setListError records the outcome of a list call of resource.
+func (c *Controller) setListError(resource string, err error) {
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+	if err == nil {
+		delete(c.listFailures, resource)
+		return
+	}
+	failure := c.listFailures[resource]
+	c.listFailures[resource] = listFailure{attempts: failure.attempts + 1, err: err}
+}
+
+// ListFailures returns how many times in a row the list of the resource
+// failing the most has failed, along with its last error. It is zero once
+// every list has succeeded. While the caches sync it tells a cluster which
+// can't be reached from one which is slow to answer.
+func (c *Controller) ListFailures() (int, error) {
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+	var worst listFailure
+	for _, failure := range c.listFailures {
+		if failure.attempts > worst.attempts {
+			worst = failure
+		}
+	}
+	return worst.attempts, worst.err
+}
+
+// watchErrorHandler marks resource as unhealthy whenever its watch is
+// dropped, the reflector then backs off and reconnects on its own.
+func (c *Controller) watchErrorHandler(resource string) cache.WatchErrorHandler {
+	return func(r *cache.Reflector, err error) {
+		c.logger.Debug("watch failed, reconnecting", "resource", resource, "err", err)
+		c.setWatchError(resource, err)
+		c.reportError(fmt.Errorf("watch of %s failed, got err: %w", resource, err))
+		cache.DefaultWatchErrorHandler(r, err)
+	}
+}
+
+// trackHealth wraps lw so that resource is marked healthy again as soon as a
+// list or watch call succeeds.
+func (c *Controller) trackHealth(resource string, lw *cache.ListWatch) *cache.ListWatch {
+	list, watchFunc := lw.ListFunc, lw.WatchFunc
+	return &cache.ListWatch{
+		ListFunc: func(options meta_v1.ListOptions) (runtime.Object, error) {
+			obj, err := list(options)
+			c.setListError(resource, err)
+			if err == nil {
+				c.setWatchError(resource, nil)
+			}
+			return obj, err
+		},
+		WatchFunc: func(options meta_v1.ListOptions) (watch.Interface, error) {
+			w, err := watchFunc(options)
+			if err == nil {
+				c.logger.Debug("watch established", "resource", resource)
+				c.setWatchError(resource, nil)
+			}
+			return w, err
+		},
+		DisableChunking: lw.DisableChunking,
+	}
+}
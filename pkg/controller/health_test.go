@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// newTestReflector returns a reflector for the watch error handlers to be
+// called with, it is never run.
+func newTestReflector() *cache.Reflector {
+	return cache.NewReflector(&cache.ListWatch{}, &appsv1.Deployment{}, cache.NewStore(cache.MetaNamespaceKeyFunc), 0)
+}
+
+func TestWatchErrorsFlipHealth(t *testing.T) {
+	c := newTestController(t)
+	if !c.Healthy() {
+		t.Fatal("Healthy() = false before any watch failed")
+	}
+
+	c.watchErrorHandler("deployments")(newTestReflector(), errors.New("connection refused"))
+	if c.Healthy() {
+		t.Error("Healthy() = true after the deployments watch failed")
+	}
+	select {
+	case err := <-c.Errors():
+		if err == nil {
+			t.Error("Errors() received nil")
+		}
+	default:
+		t.Error("the watch error wasn't reported on Errors()")
+	}
+
+	c.watchErrorHandler("pods")(newTestReflector(), errors.New("connection refused"))
+	c.setWatchError("deployments", nil)
+	if c.Healthy() {
+		t.Error("Healthy() = true while the pods watch is still failing")
+	}
+	c.setWatchError("pods", nil)
+	if !c.Healthy() {
+		t.Error("Healthy() = false once every watch recovered")
+	}
+}
+
+func TestSuccessfulCallsRestoreHealth(t *testing.T) {
+	listErr := errors.New("connection refused")
+	lw := &cache.ListWatch{
+		ListFunc: func(meta_v1.ListOptions) (runtime.Object, error) {
+			if listErr != nil {
+				return nil, listErr
+			}
+			return &appsv1.DeploymentList{}, nil
+		},
+		WatchFunc: func(meta_v1.ListOptions) (watch.Interface, error) {
+			return watch.NewFake(), nil
+		},
+	}
+
+	c := newTestController(t)
+	tracked := c.trackHealth("deployments", lw)
+
+	c.watchErrorHandler("deployments")(newTestReflector(), listErr)
+	if _, err := tracked.List(meta_v1.ListOptions{}); err == nil {
+		t.Fatal("List() succeeded, want it to fail")
+	}
+	if c.Healthy() {
+		t.Error("Healthy() = true after a failed list")
+	}
+	if attempts, err := c.ListFailures(); attempts != 1 || !errors.Is(err, listErr) {
+		t.Errorf("ListFailures() = %d, %v, want 1, %v", attempts, err, listErr)
+	}
+
+	listErr = nil
+	if _, err := tracked.List(meta_v1.ListOptions{}); err != nil {
+		t.Fatalf("List() failed, got err: %v", err)
+	}
+	if !c.Healthy() {
+		t.Error("Healthy() = false after a successful list")
+	}
+	if attempts, _ := c.ListFailures(); attempts != 0 {
+		t.Errorf("ListFailures() = %d after a successful list, want 0", attempts)
+	}
+
+	c.watchErrorHandler("deployments")(newTestReflector(), errors.New("connection reset"))
+	if _, err := tracked.Watch(meta_v1.ListOptions{}); err != nil {
+		t.Fatalf("Watch() failed, got err: %v", err)
+	}
+	if !c.Healthy() {
+		t.Error("Healthy() = false after a successful watch")
+	}
+}
+
+func TestForbiddenWatchIsDenied(t *testing.T) {
+	c := newTestController(t)
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("no RBAC policy matched"))
+
+	c.watchErrorHandler("pods")(newTestReflector(), forbidden)
+	if !c.Denied("pods") {
+		t.Error("Denied(pods) = false after a forbidden watch")
+	}
+	if c.Denied("deployments") {
+		t.Error("Denied(deployments) = true, only pods were forbidden")
+	}
+	// Access to the other resources is enough to carry on
+	if err := c.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
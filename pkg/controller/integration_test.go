@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+// syncTimeout bounds every wait of the integration test.
+const syncTimeout = 10 * time.Second
+
+// waitForDeployments waits until the deployments of c satisfy done.
+func waitForDeployments(t *testing.T, c *Controller, what string, done func(map[string]*appsv1.Deployment) bool) {
+	t.Helper()
+	err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, syncTimeout, true, func(context.Context) (bool, error) {
+		return done(c.Deployments()), nil
+	})
+	if err != nil {
+		t.Fatalf("timed out waiting for %s, Deployments() = %v", what, c.Deployments())
+	}
+}
+
+func TestControllerFollowsFakeClientset(t *testing.T) {
+	clientset := fake.NewSimpleClientset(newTestDeployment("default", "api"))
+
+	// The fake clientset drops the events sent before a watch is
+	// established, so wait for the deployment watch before changing anything
+	watching := make(chan struct{})
+	clientset.PrependWatchReactor("deployments", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		w, err := clientset.Tracker().Watch(action.GetResource(), action.GetNamespace())
+		if err != nil {
+			return false, nil, err
+		}
+		close(watching)
+		return true, w, nil
+	})
+
+	c := NewController(clientset, WithLogOutput(io.Discard))
+	stop := make(chan struct{})
+	defer close(stop)
+	go c.Run(stop)
+
+	timeout := make(chan struct{})
+	timer := time.AfterFunc(syncTimeout, func() { close(timeout) })
+	defer timer.Stop()
+	if !cache.WaitForCacheSync(timeout, c.HasSynced) {
+		t.Fatal("timed out waiting for the caches to sync")
+	}
+	select {
+	case <-watching:
+	case <-timeout:
+		t.Fatal("timed out waiting for the deployment watch")
+	}
+
+	waitForDeployments(t, c, "the listed deployment", func(d map[string]*appsv1.Deployment) bool {
+		return len(d) == 1 && d["default/api"] != nil
+	})
+
+	deployments := clientset.AppsV1().Deployments("default")
+	web := newTestDeployment("default", "web")
+	if _, err := deployments.Create(context.TODO(), web, meta_v1.CreateOptions{}); err != nil {
+		t.Fatalf("Create() failed, got err: %v", err)
+	}
+	waitForDeployments(t, c, "the created deployment", func(d map[string]*appsv1.Deployment) bool {
+		return len(d) == 2 && d["default/web"] != nil
+	})
+
+	replicas := int32(3)
+	web.Spec.Replicas = &replicas
+	if _, err := deployments.Update(context.TODO(), web, meta_v1.UpdateOptions{}); err != nil {
+		t.Fatalf("Update() failed, got err: %v", err)
+	}
+	waitForDeployments(t, c, "the updated deployment", func(d map[string]*appsv1.Deployment) bool {
+		return d["default/web"] != nil && d["default/web"].Spec.Replicas != nil && *d["default/web"].Spec.Replicas == 3
+	})
+
+	if err := deployments.Delete(context.TODO(), "api", meta_v1.DeleteOptions{}); err != nil {
+		t.Fatalf("Delete() failed, got err: %v", err)
+	}
+	waitForDeployments(t, c, "the deleted deployment to go", func(d map[string]*appsv1.Deployment) bool {
+		return len(d) == 1 && d["default/web"] != nil
+	})
+}
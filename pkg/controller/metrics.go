@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// metricsRegistry holds the controller metrics, it is kept separate from
+	// the default registry so that only the metrics below are exposed.
+	metricsRegistry = prometheus.NewRegistry()
+
+	informerEventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_tui_informer_events_total",
+		Help: "Number of informer events received, by resource and event type.",
+	}, []string{"resource", "event"})
+
+	syncErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_tui_sync_errors_total",
+		Help: "Number of failed syncs, by resource.",
+	}, []string{"resource"})
+)
+
+func init() {
+	metricsRegistry.MustRegister(informerEventsTotal, syncErrorsTotal)
+}
+
+// MetricsHandler returns an http.Handler serving the controller metrics in the
+// Prometheus exposition format.
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{})
+}
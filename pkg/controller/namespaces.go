@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ListNamespaces returns the names of all namespaces in the cluster matching
+// the namespace selector and not excluded, sorted.
+func (c *Controller) ListNamespaces() ([]string, error) {
+	list, err := c.clientset.CoreV1().Namespaces().List(context.TODO(), meta_v1.ListOptions{LabelSelector: c.namespaceSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces, got err: %w", classify(err))
+	}
+
+	names := make([]string, 0, len(list.Items))
+	for _, namespace := range list.Items {
+		if _, ok := c.excludedNamespaces[namespace.GetName()]; !ok {
+			names = append(names, namespace.GetName())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Namespace returns the namespace being watched, empty meaning all.
+func (c *Controller) Namespace() string {
+	c.informerMutex.Lock()
+	defer c.informerMutex.Unlock()
+	return c.namespace
+}
+
+// SetNamespace restricts the watches to namespace, or all namespaces when
+// empty. The informers are rebuilt and the current objects are dropped until
+// they have been listed again.
+func (c *Controller) SetNamespace(namespace string) {
+	c.informerMutex.Lock()
+	defer c.informerMutex.Unlock()
+	if namespace == c.namespace {
+		return
+	}
+
+	c.namespace = namespace
+	c.restartInformers()
+}
+
+// restartInformers replaces the informers, restarting them if they were
+// running. The caller must hold informerMutex.
+func (c *Controller) restartInformers() {
+	running := c.informerStop != nil
+	if running {
+		close(c.informerStop)
+	}
+
+	c.buildInformers()
+	// The stores have been emptied
+	c.notifyChange()
+
+	if running {
+		c.startInformers()
+	}
+}
+
+// inSelectedNamespaces reports whether obj lives in one of the namespaces
+// matching the namespace selector and not excluded. Cluster scoped objects
+// always do, as does everything when no selector is set and no namespace
+// excluded.
+func (c *Controller) inSelectedNamespaces(obj interface{}) bool {
+	if c.namespaceSelector == "" && len(c.excludedNamespaces) == 0 {
+		return true
+	}
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return false
+	}
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return false
+	}
+	if namespace == "" {
+		return true
+	}
+	if _, ok := c.excludedNamespaces[namespace]; ok {
+		return false
+	}
+	if c.namespaceSelector == "" {
+		return true
+	}
+
+	c.namespaceMutex.RLock()
+	defer c.namespaceMutex.RUnlock()
+	_, ok := c.selectedNamespaces[namespace]
+	return ok
+}
+
+// resolveNamespaces lists the namespaces matching the namespace selector,
+// rebuilding the informers when they differ from the ones resolved last.
+func (c *Controller) resolveNamespaces() {
+	names, err := c.ListNamespaces()
+	if err != nil {
+		c.logger.Debug("Resolving the namespace selector failed", "selector", c.namespaceSelector, "err", err)
+		c.reportError(fmt.Errorf("failed to resolve the namespace selector %q, got err: %w", c.namespaceSelector, err))
+		return
+	}
+
+	selected := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		selected[name] = struct{}{}
+	}
+
+	c.namespaceMutex.Lock()
+	changed := !maps.Equal(selected, c.selectedNamespaces)
+	c.selectedNamespaces = selected
+	c.namespaceMutex.Unlock()
+
+	if changed {
+		c.logger.Debug("Namespace selector resolved", "selector", c.namespaceSelector, "namespaces", names)
+		// The informers have filtered out the objects of newly selected
+		// namespaces, so they have to be listed again
+		c.informerMutex.Lock()
+		c.restartInformers()
+		c.informerMutex.Unlock()
+	}
+}
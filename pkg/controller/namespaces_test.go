@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSetNamespaceDropsQueuedKeys(t *testing.T) {
+	c := newTestController(t, WithNamespace("old"))
+
+	var mutex sync.Mutex
+	var synced []string
+	done := make(chan struct{})
+	c.deployments.syncFunc = func(key string) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		synced = append(synced, key)
+		if key == "new/api" {
+			close(done)
+		}
+		return nil
+	}
+
+	c.deployments.getQueue().Add("old/web")
+	c.SetNamespace("new")
+	c.deployments.getQueue().Add("new/api")
+
+	stopped := make(chan struct{})
+	go func() {
+		c.deployments.work(c)
+		close(stopped)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for new/api to be synced")
+	}
+	c.deployments.shutDown()
+	<-stopped
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(synced) != 1 {
+		t.Errorf("synced %v, want only new/api", synced)
+	}
+}
+
+func TestSetNamespaceClearsObjects(t *testing.T) {
+	c := newTestController(t)
+	c.deployments.set("old/web", newTestDeployment("old", "web"))
+
+	c.SetNamespace("new")
+	if got := c.Deployments(); len(got) != 0 {
+		t.Errorf("Deployments() = %v after switching namespace, want none", got)
+	}
+	if got := c.Namespace(); got != "new" {
+		t.Errorf("Namespace() = %q, want %q", got, "new")
+	}
+}
+
+func TestResolveNamespaces(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "shop", Labels: map[string]string{"env": "prod"}}},
+		&corev1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "blog", Labels: map[string]string{"env": "prod"}}},
+		&corev1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "sandbox", Labels: map[string]string{"env": "dev"}}},
+	)
+	// Nothing is logged at the default level, as stdout may be the -once or
+	// -compact output
+	var logs bytes.Buffer
+	c := NewController(clientset, WithNamespaceSelector("env=prod"), WithLogOutput(&logs))
+
+	c.resolveNamespaces()
+	for _, namespace := range []string{"shop", "blog"} {
+		if !c.inSelectedNamespaces(newTestDeployment(namespace, "web")) {
+			t.Errorf("namespace %s isn't selected", namespace)
+		}
+	}
+	if c.inSelectedNamespaces(newTestDeployment("sandbox", "web")) {
+		t.Error("namespace sandbox is selected")
+	}
+
+	clientset.PrependReactor("list", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	c.resolveNamespaces()
+	select {
+	case err := <-c.Errors():
+		if err == nil {
+			t.Error("Errors() received nil, want the failure to resolve the selector")
+		}
+	default:
+		t.Error("the failure to resolve the selector wasn't reported on Errors()")
+	}
+	// The namespaces resolved last are kept
+	if !c.inSelectedNamespaces(newTestDeployment("shop", "web")) {
+		t.Error("namespace shop is no longer selected after a failure")
+	}
+
+	if logs.Len() > 0 {
+		t.Errorf("resolveNamespaces() logged %q at the default level, want nothing", logs.String())
+	}
+}
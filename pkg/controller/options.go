@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"io"
+	"log/slog"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/workqueue"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// DefaultFieldManager is the field manager of server-side applies unless
+// configured with WithFieldManager.
+const DefaultFieldManager = "k8s-tui"
+
+// Option configures a Controller created by NewController.
+type Option func(*options)
+
+type options struct {
+	// newRateLimiter creates the rate limiter of each workqueue, every queue
+	// gets its own so that failures of one resource type don't delay another.
+	newRateLimiter func() workqueue.TypedRateLimiter[string]
+	logOutput      io.Writer
+	logLevel       slog.Level
+	fieldSelector  string
+	// namespaceSelector is a label selector of the namespaces to watch
+	namespaceSelector string
+	readOnly          bool
+	namespace         string
+	deploymentName    string
+	fieldManager      string
+	trimObjects       bool
+	custom            *customResource
+	workers           int
+	listPageSize      int64
+	metrics           metricsclient.Interface
+
+	// excludedNamespaces are the namespaces whose objects are ignored
+	excludedNamespaces []string
+}
+
+func defaultOptions() *options {
+	return &options{
+		newRateLimiter: workqueue.DefaultTypedControllerRateLimiter[string],
+		logOutput:      io.Discard,
+		logLevel:       slog.LevelInfo,
+		fieldManager:   DefaultFieldManager,
+		workers:        1,
+	}
+}
+
+// WithRetryBackoff sets the exponential backoff applied when retrying a failed
+// sync, starting at baseDelay and doubling up to maxDelay. The defaults are
+// those of workqueue.DefaultTypedControllerRateLimiter.
+func WithRetryBackoff(baseDelay, maxDelay time.Duration) Option {
+	return func(o *options) {
+		o.newRateLimiter = func() workqueue.TypedRateLimiter[string] {
+			return workqueue.NewTypedMaxOfRateLimiter(
+				workqueue.NewTypedItemExponentialFailureRateLimiter[string](baseDelay, maxDelay),
+				// Keep the overall rate limit of the default limiter
+				&workqueue.TypedBucketRateLimiter[string]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+			)
+		}
+	}
+}
+
+// WithLogOutput sets where the controller logs are written, by default they
+// are discarded as stdout belongs to the TUI.
+func WithLogOutput(w io.Writer) Option {
+	return func(o *options) {
+		o.logOutput = w
+	}
+}
+
+// WithLogLevel sets the minimum level of the controller logs, info by default.
+func WithLogLevel(level slog.Level) Option {
+	return func(o *options) {
+		o.logLevel = level
+	}
+}
+
+// WithFieldSelector restricts every watch to the objects matching the field
+// selector, e.g. metadata.name!=kube-dns. Selectors on fields a resource
+// doesn't support make its list fail, which is reported by Err.
+func WithFieldSelector(selector string) Option {
+	return func(o *options) {
+		o.fieldSelector = selector
+	}
+}
+
+// WithListPageSize lists the objects in pages of size objects, so that the
+// initial sync of a large resource doesn't fetch it all at once. Zero keeps
+// client-go's default page size. The API server ignores it for the lists it
+// serves from its watch cache.
+func WithListPageSize(size int64) Option {
+	return func(o *options) {
+		o.listPageSize = size
+	}
+}
+
+// WithNamespace sets the namespace watched when the controller starts, all of
+// them when empty. SetNamespace changes it later on.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithNamespaceSelector only watches the objects of namespaces matching the
+// label selector, e.g. env=prod. The matching namespaces are resolved when
+// the controller starts and again every minute.
+func WithNamespaceSelector(selector string) Option {
+	return func(o *options) {
+		o.namespaceSelector = selector
+	}
+}
+
+// WithExcludedNamespaces ignores the objects of the given namespaces, which
+// are left out of every snapshot and of ListNamespaces. They are still listed
+// and watched when all namespaces are, so this is no substitute for
+// permissions.
+func WithExcludedNamespaces(namespaces []string) Option {
+	return func(o *options) {
+		o.excludedNamespaces = append(o.excludedNamespaces, namespaces...)
+	}
+}
+
+// WithReadOnly disables every action changing the cluster, they fail with
+// ErrReadOnly instead.
+func WithReadOnly(readOnly bool) Option {
+	return func(o *options) {
+		o.readOnly = readOnly
+	}
+}
+
+// WithDeploymentName only watches the deployments with the given name, the
+// other resources are watched as usual.
+func WithDeploymentName(name string) Option {
+	return func(o *options) {
+		o.deploymentName = name
+	}
+}
+
+// WithFieldManager sets the field manager owning the fields set by
+// server-side applies.
+func WithFieldManager(name string) Option {
+	return func(o *options) {
+		o.fieldManager = name
+	}
+}
+
+// WithTrimmedObjects drops the managed fields and the last applied
+// configuration annotation of every object before it is cached, which the
+// snapshots then lack as well.
+func WithTrimmedObjects(trim bool) Option {
+	return func(o *options) {
+		o.trimObjects = trim
+	}
+}
+
+// WithCustomResource also watches the objects of gvr, such as those of a
+// custom resource definition, through the dynamic client. They are kept
+// unstructured, see CustomResources. Cluster scoped resources are watched
+// across namespaces whatever namespace is set.
+func WithCustomResource(client dynamic.Interface, gvr schema.GroupVersionResource, namespaced bool) Option {
+	return func(o *options) {
+		o.custom = &customResource{client: client, gvr: gvr, namespaced: namespaced}
+	}
+}
+
+// WithWorkers sets how many workers sync the objects of each resource, one by
+// default. More workers keep up better with clusters where objects change
+// often. Counts below one are ignored.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.workers = n
+		}
+	}
+}
+
+// WithMetrics sets the client PodMetrics fetches the usage of the pods with.
+// Without one PodMetrics always fails with ErrMetricsUnavailable.
+func WithMetrics(client metricsclient.Interface) Option {
+	return func(o *options) {
+		o.metrics = client
+	}
+}
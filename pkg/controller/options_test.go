@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestLogOutput(t *testing.T) {
+	// stdout belongs to the TUI, so nothing is logged unless asked for
+	if o := defaultOptions(); o.logOutput != io.Discard {
+		t.Errorf("default log output = %v, want io.Discard", o.logOutput)
+	}
+
+	var logs bytes.Buffer
+	c := newTestController(t, WithLogOutput(&logs), WithLogLevel(slog.LevelDebug))
+	c.logger.Debug("syncing deployment", "key", "default/web")
+	if !bytes.Contains(logs.Bytes(), []byte(`"key":"default/web"`)) {
+		t.Errorf("WithLogOutput() logged %q, want the debug line", logs.String())
+	}
+}
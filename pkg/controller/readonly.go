@@ -0,0 +1,21 @@
+package controller
+
+import "errors"
+
+// ErrReadOnly is returned by the actions of a controller created with
+// WithReadOnly.
+var ErrReadOnly = errors.New("read-only mode, changes to the cluster are disabled")
+
+// ReadOnly reports whether the actions changing the cluster are disabled.
+func (c *Controller) ReadOnly() bool {
+	return c.readOnly
+}
+
+// checkWritable fails with ErrReadOnly when the actions changing the cluster
+// are disabled.
+func (c *Controller) checkWritable() error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
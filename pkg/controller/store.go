@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// store is the part of a resourceStore which the controller drives without
+// knowing the type of its objects.
+type store interface {
+	// build replaces the informer with one watching the controller namespace
+	// and drops the objects seen so far.
+	build(c *Controller)
+	run(stopCh <-chan struct{})
+	hasSynced() bool
+	// work processes the queue until it is shut down.
+	work(c *Controller)
+	shutDown()
+	// queueLen returns the number of keys waiting to be synced.
+	queueLen() int
+	// name returns the resource the store holds, e.g. deployments.
+	name() string
+}
+
+// resourceStore wraps the informer and workqueue of a single resource type,
+// mirroring its objects into a synchronized map keyed like the informer cache.
+type resourceStore[T meta_v1.Object] struct {
+	resource       string
+	objType        runtime.Object
+	newListWatch   func(namespace string) *cache.ListWatch
+	newRateLimiter func() workqueue.TypedRateLimiter[string]
+	// syncFunc processes a key taken from the queue, defaulting to sync
+	syncFunc func(key string) error
+
+	mutex    sync.RWMutex
+	informer cache.SharedIndexInformer
+	// queue holds the keys changed in the cache of informer, it is replaced
+	// along with informer
+	queue   workqueue.TypedRateLimitingInterface[string]
+	stopped bool // whether the store has been shut down
+	objects map[string]T
+}
+
+// newResourceStore creates the store of resource, listing and watching its
+// objects with the list watch returned by newListWatch for a namespace. Each
+// queue of the store gets a rate limiter created by newRateLimiter.
+func newResourceStore[T meta_v1.Object](resource string, objType runtime.Object, newRateLimiter func() workqueue.TypedRateLimiter[string], newListWatch func(namespace string) *cache.ListWatch) *resourceStore[T] {
+	s := &resourceStore[T]{
+		resource:       resource,
+		objType:        objType,
+		newListWatch:   newListWatch,
+		newRateLimiter: newRateLimiter,
+		objects:        make(map[string]T),
+	}
+	s.syncFunc = s.sync
+	return s
+}
+
+func (s *resourceStore[T]) build(c *Controller) {
+	queue := workqueue.NewTypedRateLimitingQueue(s.newRateLimiter())
+	informer := c.newInformer(s.resource, s.newListWatch(c.namespace), s.objType, queue)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// The keys queued so far belong to the previous informer, whose objects
+	// are gone, see work
+	if s.queue != nil {
+		s.queue.ShutDown()
+	}
+	if s.stopped {
+		queue.ShutDown()
+	}
+	s.informer = informer
+	s.queue = queue
+	// Clear rather than replace the map, it may be shared with callers
+	clear(s.objects)
+}
+
+func (s *resourceStore[T]) run(stopCh <-chan struct{}) {
+	s.getInformer().Run(stopCh)
+}
+
+func (s *resourceStore[T]) name() string {
+	return s.resource
+}
+
+func (s *resourceStore[T]) hasSynced() bool {
+	return s.getInformer().HasSynced()
+}
+
+func (s *resourceStore[T]) work(c *Controller) {
+	for {
+		queue := s.getQueue()
+		sync := func(key string) error {
+			// Drop the keys left in a replaced queue rather than syncing
+			// them against the cache of another namespace
+			if s.getQueue() != queue {
+				return nil
+			}
+			return s.syncFunc(key)
+		}
+		for c.processNextItem(s.resource, queue, sync) {
+		}
+
+		s.mutex.RLock()
+		stopped := s.stopped
+		s.mutex.RUnlock()
+		if stopped {
+			return
+		}
+	}
+}
+
+func (s *resourceStore[T]) shutDown() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stopped = true
+	s.queue.ShutDown()
+}
+
+func (s *resourceStore[T]) queueLen() int {
+	return s.getQueue().Len()
+}
+
+func (s *resourceStore[T]) getInformer() cache.SharedIndexInformer {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.informer
+}
+
+func (s *resourceStore[T]) getQueue() workqueue.TypedRateLimitingInterface[string] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.queue
+}
+
+// sync mirrors the state of the object stored under key in the informer
+// cache.
+func (s *resourceStore[T]) sync(key string) error {
+	obj, exists, err := s.getInformer().GetIndexer().GetByKey(key)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		s.delete(key)
+		return nil
+	}
+
+	typed, err := castObj[T](obj, s.objType)
+	if err != nil {
+		return err
+	}
+
+	s.set(key, typed)
+	return nil
+}
+
+func (s *resourceStore[T]) set(key string, obj T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.objects[key] = obj
+}
+
+func (s *resourceStore[T]) delete(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.objects, key)
+}
+
+// snapshot returns a copy of the current objects, safe to read while the
+// store keeps syncing.
+func (s *resourceStore[T]) snapshot() map[string]T {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	objects := make(map[string]T, len(s.objects))
+	for key, obj := range s.objects {
+		objects[key] = obj
+	}
+	return objects
+}
+
+// errUnexpectedType is wrapped by the errors of castObj. Syncing such an object
+// again cannot succeed, so it is never retried.
+var errUnexpectedType = errors.New("unexpected object type")
+
+// castObj casts obj to T, the type of objType, describing obj in the error if
+// it is anything else.
+func castObj[T meta_v1.Object](obj interface{}, objType runtime.Object) (T, error) {
+	typed, ok := obj.(T)
+	if ok {
+		return typed, nil
+	}
+
+	kind := strings.ToLower(reflect.TypeOf(objType).Elem().Name())
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return typed, fmt.Errorf("could not cast obj to %s, %w, failed to create accessor, got err: %w", kind, errUnexpectedType, err)
+	}
+	return typed, fmt.Errorf("could not cast obj %s/%s (uid: %s) to %s, got err: %w", accessor.GetNamespace(), accessor.GetName(), accessor.GetUID(), kind, errUnexpectedType)
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// newTestStore returns a deployment store whose informer is never built.
+func newTestStore() *resourceStore[*appsv1.Deployment] {
+	return newResourceStore[*appsv1.Deployment]("deployments", &appsv1.Deployment{}, workqueue.DefaultTypedControllerRateLimiter[string], func(string) *cache.ListWatch {
+		return &cache.ListWatch{}
+	})
+}
+
+func TestResourceStoreSetAndDelete(t *testing.T) {
+	s := newTestStore()
+	web := newTestDeployment("default", "web")
+	api := newTestDeployment("default", "api")
+
+	s.set("default/web", web)
+	s.set("default/api", api)
+	if got := s.snapshot(); len(got) != 2 || got["default/web"] != web || got["default/api"] != api {
+		t.Errorf("snapshot() = %v after two sets, want web and api", got)
+	}
+
+	updated := newTestDeployment("default", "web")
+	updated.ResourceVersion = "2"
+	s.set("default/web", updated)
+	if got := s.snapshot()["default/web"]; got != updated {
+		t.Errorf("snapshot()[default/web] = %v, want the updated deployment", got)
+	}
+
+	s.delete("default/web")
+	if got := s.snapshot(); len(got) != 1 || got["default/api"] != api {
+		t.Errorf("snapshot() = %v after deleting web, want only api", got)
+	}
+
+	// Deleting a missing key is a no-op
+	s.delete("default/missing")
+	if got := s.snapshot(); len(got) != 1 {
+		t.Errorf("snapshot() = %v after deleting a missing key, want only api", got)
+	}
+}
+
+func TestResourceStoreSnapshotIsolation(t *testing.T) {
+	s := newTestStore()
+	s.set("default/web", newTestDeployment("default", "web"))
+
+	snapshot := s.snapshot()
+	s.set("default/api", newTestDeployment("default", "api"))
+	s.delete("default/web")
+	if len(snapshot) != 1 || snapshot["default/web"] == nil {
+		t.Errorf("snapshot = %v, changes to the store leaked into it", snapshot)
+	}
+
+	delete(snapshot, "default/web")
+	snapshot["default/other"] = newTestDeployment("default", "other")
+	if got := s.snapshot(); len(got) != 1 || got["default/api"] == nil {
+		t.Errorf("snapshot() = %v, changes to an earlier snapshot leaked into the store", got)
+	}
+}
+
+func TestCastObj(t *testing.T) {
+	web := newTestDeployment("default", "web")
+	got, err := castObj[*appsv1.Deployment](web, &appsv1.Deployment{})
+	if err != nil || got != web {
+		t.Errorf("castObj(deployment) = %v, %v, want the deployment", got, err)
+	}
+
+	pod := &corev1.Pod{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "web-1", UID: "1234"}}
+	_, err = castObj[*appsv1.Deployment](pod, &appsv1.Deployment{})
+	if !errors.Is(err, errUnexpectedType) {
+		t.Fatalf("castObj(pod) = %v, want an errUnexpectedType", err)
+	}
+	for _, part := range []string{"default/web-1", "1234", "deployment"} {
+		if !strings.Contains(err.Error(), part) {
+			t.Errorf("castObj(pod) = %q, want it to mention %q", err, part)
+		}
+	}
+
+	// Objects without metadata can't be described
+	_, err = castObj[*appsv1.Deployment]("default/web", &appsv1.Deployment{})
+	if !errors.Is(err, errUnexpectedType) {
+		t.Errorf("castObj(string) = %v, want an errUnexpectedType", err)
+	}
+}
+
+func TestResourceStoreSync(t *testing.T) {
+	c := newTestController(t)
+	s := c.pods
+	indexer := s.getInformer().GetIndexer()
+
+	pod := &corev1.Pod{ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "web-1"}}
+	if err := indexer.Add(pod); err != nil {
+		t.Fatalf("Add() failed, got err: %v", err)
+	}
+	if err := s.sync("default/web-1"); err != nil {
+		t.Fatalf("sync() failed, got err: %v", err)
+	}
+	if got := c.Pods()["default/web-1"]; got != pod {
+		t.Errorf("Pods()[default/web-1] = %v, want the pod", got)
+	}
+
+	if err := indexer.Delete(pod); err != nil {
+		t.Fatalf("Delete() failed, got err: %v", err)
+	}
+	if err := s.sync("default/web-1"); err != nil {
+		t.Fatalf("sync() failed, got err: %v", err)
+	}
+	if got := c.Pods(); len(got) != 0 {
+		t.Errorf("Pods() = %v after the pod was deleted, want none", got)
+	}
+
+	if err := indexer.Add(newTestDeployment("default", "web-2")); err != nil {
+		t.Fatalf("Add() failed, got err: %v", err)
+	}
+	if err := s.sync("default/web-2"); !errors.Is(err, errUnexpectedType) {
+		t.Errorf("sync() of a deployment in the pod cache = %v, want an errUnexpectedType", err)
+	}
+}
@@ -0,0 +1,26 @@
+package controller
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// lastAppliedAnnotation holds the manifest last applied by kubectl apply,
+// often the largest part of an object.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// trimObject drops the managed fields and the last applied configuration of
+// obj before the informers store it. Neither is shown in the tables, and on
+// large clusters they make up much of the memory the caches use.
+func trimObject(obj interface{}) (interface{}, error) {
+	accessor, ok := obj.(meta_v1.Object)
+	if !ok {
+		// Such as the tombstones of deleted objects
+		return obj, nil
+	}
+	accessor.SetManagedFields(nil)
+	if annotations := accessor.GetAnnotations(); annotations[lastAppliedAnnotation] != "" {
+		delete(annotations, lastAppliedAnnotation)
+		accessor.SetAnnotations(annotations)
+	}
+	return obj, nil
+}
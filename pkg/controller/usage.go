@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// ErrMetricsUnavailable is returned by PodMetrics when the cluster doesn't
+// serve the metrics API, which metrics-server provides.
+var ErrMetricsUnavailable = errors.New("metrics are unavailable, metrics-server isn't installed")
+
+// metricsAvailable asks the API server once whether it serves the metrics
+// API, failing with ErrMetricsUnavailable when it doesn't.
+func (c *Controller) metricsAvailable() error {
+	c.metricsOnce.Do(func() {
+		_, err := c.clientset.Discovery().ServerResourcesForGroupVersion(metricsv1beta1.SchemeGroupVersion.String())
+		switch {
+		case apierrors.IsNotFound(err):
+			c.metricsErr = ErrMetricsUnavailable
+		case err != nil:
+			c.metricsErr = fmt.Errorf("failed to discover the metrics API, got err: %w", classify(err))
+		}
+	})
+	return c.metricsErr
+}
+
+// PodMetrics fetches the current CPU and memory usage of the pods in the
+// watched namespace, keyed by namespace/name. It fails with
+// ErrMetricsUnavailable without a metrics client or metrics-server.
+func (c *Controller) PodMetrics() (map[string]*metricsv1beta1.PodMetrics, error) {
+	if c.metrics == nil {
+		return nil, ErrMetricsUnavailable
+	}
+	if err := c.metricsAvailable(); err != nil {
+		return nil, err
+	}
+
+	list, err := c.metrics.MetricsV1beta1().PodMetricses(c.Namespace()).List(context.TODO(), meta_v1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod metrics, got err: %w", classify(err))
+	}
+	usage := make(map[string]*metricsv1beta1.PodMetrics, len(list.Items))
+	for i := range list.Items {
+		podMetrics := &list.Items[i]
+		usage[podMetrics.Namespace+"/"+podMetrics.Name] = podMetrics
+	}
+	return usage, nil
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Units of ageString beyond those of time.Duration.
+const (
+	day  = 24 * time.Hour
+	year = 365 * day
+)
+
+// ageString renders the time elapsed since t in its largest unit, e.g. 3d, or
+// when verbose in its two largest units, e.g. 3d4h. A zero second unit is left
+// out and times in the future render as 0s.
+func ageString(t time.Time, verbose bool) string {
+	return formatAge(max(time.Since(t), 0), verbose)
+}
+
+// formatAge renders d like ageString.
+func formatAge(d time.Duration, verbose bool) string {
+	units := []struct {
+		size   time.Duration
+		suffix string
+	}{
+		{year, "y"},
+		{day, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	}
+
+	for i, unit := range units {
+		if d < unit.size && unit.size != time.Second {
+			continue
+		}
+		s := fmt.Sprintf("%d%s", d/unit.size, unit.suffix)
+		if verbose && i+1 < len(units) {
+			next := units[i+1]
+			if rest := (d % unit.size) / next.size; rest > 0 {
+				s += fmt.Sprintf("%d%s", rest, next.suffix)
+			}
+		}
+		return s
+	}
+	return "0s"
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		d           time.Duration
+		want        string
+		wantVerbose string
+	}{
+		{0, "0s", "0s"},
+		{500 * time.Millisecond, "0s", "0s"},
+		{45 * time.Second, "45s", "45s"},
+		{90 * time.Second, "1m", "1m30s"},
+		{59*time.Minute + 59*time.Second, "59m", "59m59s"},
+		{time.Hour, "1h", "1h"},
+		{3*time.Hour + 25*time.Minute, "3h", "3h25m"},
+		{day, "1d", "1d"},
+		{3*day + 4*time.Hour + 5*time.Minute, "3d", "3d4h"},
+		{364*day + 23*time.Hour, "364d", "364d23h"},
+		{year, "1y", "1y"},
+		{2*year + 45*day, "2y", "2y45d"},
+		// The second unit is whole, leftover hours are dropped
+		{year + 12*time.Hour, "1y", "1y"},
+	}
+	for _, tt := range tests {
+		if got := formatAge(tt.d, false); got != tt.want {
+			t.Errorf("formatAge(%v, false) = %q, want %q", tt.d, got, tt.want)
+		}
+		if got := formatAge(tt.d, true); got != tt.wantVerbose {
+			t.Errorf("formatAge(%v, true) = %q, want %q", tt.d, got, tt.wantVerbose)
+		}
+	}
+}
+
+func TestAgeStringInTheFuture(t *testing.T) {
+	for _, verbose := range []bool{false, true} {
+		if got := ageString(time.Now().Add(time.Hour), verbose); got != "0s" {
+			t.Errorf("ageString(in an hour, %v) = %q, want %q", verbose, got, "0s")
+		}
+	}
+}
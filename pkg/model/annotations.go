@@ -0,0 +1,63 @@
+package model
+
+import (
+	"strings"
+)
+
+// DefaultHiddenAnnotations are the comma separated annotations left out of
+// the detail view unless every annotation is shown. They are set by tools
+// rather than people and can run to pages, a trailing * matches any suffix.
+const DefaultHiddenAnnotations = "kubectl.kubernetes.io/last-applied-configuration,control-plane.alpha.kubernetes.io/leader,deprecated.daemonset.template.generation,kapp.k14s.io/original,field.cattle.io/publicEndpoints"
+
+// annotationFilter decides which annotations the detail view shows.
+type annotationFilter struct {
+	hide []string // annotations to hide, patterns as in DefaultHiddenAnnotations
+	show []string // annotations shown even though they match hide
+}
+
+// parseAnnotationFilter parses the comma separated annotations to hide and
+// the ones to show anyway.
+func parseAnnotationFilter(hide, show string) annotationFilter {
+	return annotationFilter{hide: splitList(hide), show: splitList(show)}
+}
+
+// splitList splits a comma separated list, dropping the empty entries.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// apply returns the annotations f lets through along with the number it
+// hid. The annotations themselves are left as they are.
+func (f annotationFilter) apply(annotations map[string]string) (map[string]string, int) {
+	shown := make(map[string]string, len(annotations))
+	hidden := 0
+	for k, v := range annotations {
+		if matchesAny(k, f.hide) && !matchesAny(k, f.show) {
+			hidden++
+			continue
+		}
+		shown[k] = v
+	}
+	return shown, hidden
+}
+
+// matchesAny reports whether key is one of patterns, a pattern ending in *
+// matching every key with the same prefix.
+func matchesAny(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestAnnotationFilter(t *testing.T) {
+	annotations := map[string]string{
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+		"deployment.kubernetes.io/revision":                "3",
+		"meta.helm.sh/release-name":                        "web",
+		"meta.helm.sh/release-namespace":                   "default",
+		"team":                                             "payments",
+	}
+
+	tests := []struct {
+		name       string
+		hide       string
+		show       string
+		want       []string
+		wantHidden int
+	}{
+		{
+			name:       "default deny list",
+			hide:       DefaultHiddenAnnotations,
+			want:       []string{"deployment.kubernetes.io/revision", "meta.helm.sh/release-name", "meta.helm.sh/release-namespace", "team"},
+			wantHidden: 1,
+		},
+		{
+			name:       "shown despite the deny list",
+			hide:       DefaultHiddenAnnotations,
+			show:       "kubectl.kubernetes.io/last-applied-configuration",
+			want:       []string{"deployment.kubernetes.io/revision", "kubectl.kubernetes.io/last-applied-configuration", "meta.helm.sh/release-name", "meta.helm.sh/release-namespace", "team"},
+			wantHidden: 0,
+		},
+		{
+			name:       "prefix pattern",
+			hide:       "meta.helm.sh/*",
+			want:       []string{"deployment.kubernetes.io/revision", "kubectl.kubernetes.io/last-applied-configuration", "team"},
+			wantHidden: 2,
+		},
+		{
+			name:       "shown by a prefix pattern",
+			hide:       "meta.helm.sh/*, kubectl.kubernetes.io/*",
+			show:       "meta.helm.sh/release-n*",
+			want:       []string{"deployment.kubernetes.io/revision", "meta.helm.sh/release-name", "meta.helm.sh/release-namespace", "team"},
+			wantHidden: 1,
+		},
+		{
+			name:       "everything",
+			hide:       "*",
+			show:       "team",
+			want:       []string{"team"},
+			wantHidden: 4,
+		},
+		{
+			// A pattern without a trailing * matches exactly
+			name:       "exact match only",
+			hide:       "meta.helm.sh/release,team*,",
+			want:       []string{"deployment.kubernetes.io/revision", "kubectl.kubernetes.io/last-applied-configuration", "meta.helm.sh/release-name", "meta.helm.sh/release-namespace"},
+			wantHidden: 1,
+		},
+		{
+			name:       "nothing hidden",
+			want:       []string{"deployment.kubernetes.io/revision", "kubectl.kubernetes.io/last-applied-configuration", "meta.helm.sh/release-name", "meta.helm.sh/release-namespace", "team"},
+			wantHidden: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := maps.Clone(annotations)
+			shown, hidden := parseAnnotationFilter(tt.hide, tt.show).apply(annotations)
+			keys := convertToSliceAndSort(shown)
+			if !slices.Equal(keys, tt.want) {
+				t.Errorf("apply() shows %v, want %v", keys, tt.want)
+			}
+			if hidden != tt.wantHidden {
+				t.Errorf("apply() hid %d annotations, want %d", hidden, tt.wantHidden)
+			}
+			if !maps.Equal(annotations, before) {
+				t.Errorf("apply() changed the annotations to %v", annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AClarkie/k8s-tui/pkg/controller"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// applyMethods are offered once the manifest to apply is chosen.
+var applyMethods = []string{"Create or update", "Server-side apply"}
+
+// startApply prompts for the path of a deployment manifest, then for how to
+// apply it.
+func (m *model) startApply() {
+	c := m.controller
+	m.prompt = newPrompt("Apply file: ", func(m *model, path string) tea.Cmd {
+		if path == "" {
+			return nil
+		}
+		m.picker = newPicker("Apply "+path+" with", applyMethods, -1, func(m *model, i int) tea.Cmd {
+			return m.runOperation(func() error {
+				return applyFile(c, path, i == 1)
+			})
+		})
+		return nil
+	})
+}
+
+// applyFile creates or updates the deployment described by the YAML or JSON
+// manifest at path, with server-side apply when serverSide is set.
+func applyFile(c *controller.Controller, path string, serverSide bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s, got err: %w", path, err)
+	}
+	if serverSide {
+		return c.ServerSideApplyDeployment(data)
+	}
+
+	deployment, err := controller.DecodeDeployment(data)
+	if err != nil {
+		return err
+	}
+
+	return c.ApplyDeployment(deployment)
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// backlogThreshold is the controller queue depth above which syncing is
+	// considered backed up.
+	backlogThreshold = 100
+	// backlogGrace is how long the queue has to stay backed up before it is
+	// reported, so that bursts of events don't flash a warning.
+	backlogGrace = 5 * time.Second
+)
+
+// backlogMsg carries the controller queue depth.
+type backlogMsg int
+
+func (m model) checkBacklog() tea.Cmd {
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return backlogMsg(m.controller.QueueDepth())
+	})
+}
+
+// trackBacklog records the queue depth, noting when it went over
+// backlogThreshold.
+func (m *model) trackBacklog(depth int) {
+	m.backlog = depth
+	switch {
+	case depth <= backlogThreshold:
+		m.backlogAt = time.Time{}
+	case m.backlogAt.IsZero():
+		m.backlogAt = time.Now()
+	}
+}
+
+// backlogged reports whether the queue has stayed backed up for backlogGrace.
+func (m model) backlogged() bool {
+	return !m.backlogAt.IsZero() && time.Since(m.backlogAt) >= backlogGrace
+}
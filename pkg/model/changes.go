@@ -0,0 +1,23 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// changeMsg reports that the controller snapshots have changed.
+type changeMsg struct{}
+
+// loadChanges loads the snapshots straight away, as if they had changed.
+func loadChanges() tea.Msg {
+	return changeMsg{}
+}
+
+// waitForChange blocks until the controller reports a change, without any
+// periodic wake up.
+func (m model) waitForChange() tea.Cmd {
+	changes := m.controller.Changes()
+	return func() tea.Msg {
+		<-changes
+		return changeMsg{}
+	}
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copyName copies the key of the row under the cursor to the clipboard.
+func (m *model) copyName() tea.Cmd {
+	if m.cursor >= len(m.choices) {
+		return nil
+	}
+	return m.copyText(m.choices[m.cursor])
+}
+
+// copyText copies text to the clipboard, flashing the outcome.
+func (m *model) copyText(text string) tea.Cmd {
+	if err := clipboard.WriteAll(text); err != nil {
+		return m.flash(fmt.Sprintf("Failed to copy %s, got err: %v", text, err))
+	}
+	return m.flash(fmt.Sprintf("Copied %s to the clipboard.", text))
+}
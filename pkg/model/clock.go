@@ -0,0 +1,65 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// DefaultTimeFormat is the format of the last refresh time unless configured.
+const DefaultTimeFormat = "24h"
+
+// relativeTimeFormat shows how long ago the last refresh was.
+const relativeTimeFormat = "relative"
+
+// timeLayouts are the time.Format layouts of the absolute time formats.
+var timeLayouts = map[string]string{
+	"24h":      "15:04:05",
+	"12h":      "3:04:05 PM",
+	"24h-date": "2006-01-02 15:04:05",
+	"12h-date": "2006-01-02 3:04:05 PM",
+}
+
+// timeFormatNames returns the sorted names of the accepted time formats.
+func timeFormatNames() []string {
+	names := []string{relativeTimeFormat}
+	for name := range timeLayouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validTimeFormat fails unless format is one of timeFormatNames.
+func validTimeFormat(format string) error {
+	if _, ok := timeLayouts[format]; ok || format == relativeTimeFormat {
+		return nil
+	}
+	return fmt.Errorf("unknown time format %q, expected one of %s", format, strings.Join(timeFormatNames(), ", "))
+}
+
+// formatTime renders t in format as of now, e.g. 15:04:05 or 2s ago.
+func formatTime(t, now time.Time, format string) string {
+	if format == relativeTimeFormat {
+		return duration.HumanDuration(max(now.Sub(t), 0)) + " ago"
+	}
+	return t.Format(timeLayouts[format])
+}
+
+// clockMsg redraws the relative refresh time.
+type clockMsg struct{}
+
+// tickClock redraws the status bar every second while the refresh time is
+// relative and nothing else would redraw it, as when event driven.
+func (m model) tickClock() tea.Cmd {
+	if m.timeFormat != relativeTimeFormat || !m.eventDriven {
+		return nil
+	}
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return clockMsg{}
+	})
+}
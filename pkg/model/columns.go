@@ -0,0 +1,81 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// DefaultColumns is the column spec used when none is configured.
+const DefaultColumns = "namespace,name,ready"
+
+// column is a column of the deployment table.
+type column struct {
+	entry string // the spec entry the column was parsed from
+	title string
+	value func(namespace, name string, d *appsv1.Deployment, verboseAge bool) string
+}
+
+// parseColumns parses a comma separated column spec. Each entry is one of
+// namespace, name, ready, age, label:<key> or anno:<key>.
+func parseColumns(spec string) ([]column, error) {
+	var columns []column
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		kind, key, _ := strings.Cut(entry, ":")
+
+		switch {
+		case entry == "namespace":
+			columns = append(columns, column{entry: entry, title: "Namespace", value: func(namespace, _ string, _ *appsv1.Deployment, _ bool) string {
+				return namespace
+			}})
+		case entry == "name":
+			columns = append(columns, column{entry: entry, title: "Deployment", value: func(_, name string, _ *appsv1.Deployment, _ bool) string {
+				return name
+			}})
+		case entry == "ready":
+			columns = append(columns, column{entry: entry, title: "Ready", value: func(_, _ string, d *appsv1.Deployment, _ bool) string {
+				return fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, desiredReplicas(d))
+			}})
+		case entry == "age":
+			columns = append(columns, column{entry: entry, title: "Age", value: func(_, _ string, d *appsv1.Deployment, verboseAge bool) string {
+				return ageString(d.CreationTimestamp.Time, verboseAge)
+			}})
+		case kind == "label" && key != "":
+			columns = append(columns, column{entry: entry, title: key, value: func(_, _ string, d *appsv1.Deployment, _ bool) string {
+				return d.Labels[key]
+			}})
+		case kind == "anno" && key != "":
+			columns = append(columns, column{entry: entry, title: key, value: func(_, _ string, d *appsv1.Deployment, _ bool) string {
+				return d.Annotations[key]
+			}})
+		default:
+			return nil, fmt.Errorf("unknown column %q, expected namespace, name, ready, age, label:<key> or anno:<key>", entry)
+		}
+	}
+	return columns, nil
+}
+
+// columnTitles returns the titles of columns.
+func columnTitles(columns []column) []string {
+	titles := make([]string, len(columns))
+	for i, c := range columns {
+		titles[i] = c.title
+	}
+	return titles
+}
+
+// columnValues renders the cells of the deployment stored under key, with
+// verboseAge the ages in their two largest units.
+func columnValues(columns []column, key string, d *appsv1.Deployment, verboseAge bool) string {
+	if d == nil {
+		return splitTheStringAndAddTabs(key)
+	}
+	namespace, name := splitKey(key)
+	values := make([]string, len(columns))
+	for i, c := range columns {
+		values[i] = c.value(namespace, name, d, verboseAge)
+	}
+	return strings.Join(values, "\t")
+}
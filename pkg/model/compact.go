@@ -0,0 +1,45 @@
+package model
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/AClarkie/k8s-tui/pkg/controller"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// compactInterval is how often the compact line is recomputed.
+const compactInterval = time.Second
+
+// RunCompact writes a single line summary of the deployments of controller
+// to w instead of running the TUI, rewriting it in place every second until
+// stop is closed. It suits status lines such as the one of tmux.
+func RunCompact(controller *controller.Controller, w io.Writer, stop <-chan struct{}) {
+	for !controller.HasSynced() {
+		select {
+		case <-stop:
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	ticker := time.NewTicker(compactInterval)
+	defer ticker.Stop()
+	for {
+		// Return to the start of the line and clear it before rewriting it
+		fmt.Fprintf(w, "\r\033[K%s", compactLine(controller.Deployments()))
+		select {
+		case <-stop:
+			fmt.Fprintln(w)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// compactLine renders the number of deployments and how many are unhealthy.
+func compactLine(deployments map[string]*appsv1.Deployment) string {
+	s := summarize(convertToSliceAndSort(deployments), deployments)
+	return fmt.Sprintf("deploys: %d (%d unhealthy)", s.deployments, s.unhealthy)
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+type cronJobMsg map[string]*batchv1.CronJob
+
+func (m model) checkCronJobs() tea.Cmd {
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return cronJobMsg(m.controller.CronJobs())
+	})
+}
+
+// cronJobRow appends the schedule, suspended, active and last schedule
+// columns of cronJob to its key.
+func cronJobRow(key string, cronJob *batchv1.CronJob) string {
+	if cronJob == nil {
+		return key
+	}
+
+	suspended := cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+
+	lastSchedule := placeholder
+	if t := cronJob.Status.LastScheduleTime; t != nil {
+		lastSchedule = duration.HumanDuration(time.Since(t.Time)) + " ago"
+	}
+
+	return fmt.Sprintf("%s\t%s\t%t\t%d\t%s", key, cronJob.Spec.Schedule, suspended, len(cronJob.Status.Active), lastSchedule)
+}
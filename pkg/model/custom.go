@@ -0,0 +1,88 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+type customMsg map[string]*unstructured.Unstructured
+
+// checkCustom reloads the objects of the custom resource, if the controller
+// watches one.
+func (m model) checkCustom() tea.Cmd {
+	if _, ok := m.controller.CustomResource(); !ok {
+		return nil
+	}
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return customMsg(m.controller.CustomResources())
+	})
+}
+
+// tabs returns the resources in the order they are cycled through, ending
+// with the custom resource when there is one.
+func (m model) tabs() []resource {
+	if _, ok := m.controller.CustomResource(); !ok {
+		return resources
+	}
+	return append(resources[:len(resources):len(resources)], customResource)
+}
+
+// tabName returns the name r is shown under in the tab bar, the API name of
+// the custom resource as it has no kind of its own.
+func (m model) tabName(r resource) string {
+	if gvr, ok := m.controller.CustomResource(); ok && r == customResource {
+		return gvr.Resource
+	}
+	return r.String()
+}
+
+// apiName returns the API resource name of r, which for the custom resource
+// is the one it is watched as.
+func (m model) apiName(r resource) string {
+	if gvr, ok := m.controller.CustomResource(); ok && r == customResource {
+		return gvr.Resource
+	}
+	return r.apiName()
+}
+
+// customKind returns the fully qualified name kubectl knows gvr by, e.g.
+// rollouts.v1alpha1.argoproj.io.
+func customKind(gvr schema.GroupVersionResource) string {
+	if gvr.Group == "" {
+		return gvr.Resource
+	}
+	return strings.Join([]string{gvr.Resource, gvr.Version, gvr.Group}, ".")
+}
+
+// customRow appends the age column of obj to its key.
+func customRow(key string, obj *unstructured.Unstructured, verboseAge bool) string {
+	if obj == nil {
+		return key
+	}
+	return fmt.Sprintf("%s\t%s", key, ageString(obj.GetCreationTimestamp().Time, verboseAge))
+}
+
+// customDetail renders obj as YAML, without the managed fields which would
+// bury the rest.
+func customDetail(obj *unstructured.Unstructured) string {
+	obj = obj.DeepCopy()
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return fmt.Sprintf("Manifest: failed to render, got err: %v\n", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Manifest:\n")
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+		builder.WriteString("  " + line + "\n")
+	}
+	return builder.String()
+}
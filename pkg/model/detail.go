@@ -0,0 +1,244 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// detailFetchedMsg carries the object fetched for the detail view of the row
+// stored under key.
+type detailFetchedMsg struct {
+	key string
+	obj meta_v1.Object
+	err error
+}
+
+// openDetail shows the details of the row under the cursor. Deployments are
+// fetched in full as well, as the cached copy may have been trimmed.
+func (m *model) openDetail() tea.Cmd {
+	if m.cursor >= len(m.choices) {
+		return nil
+	}
+	m.detail = m.choices[m.cursor]
+	m.detailTop = 0
+	m.detailFull = nil
+	if m.resource != deploymentsResource {
+		return nil
+	}
+
+	key, c := m.detail, m.controller
+	namespace, name := splitKey(key)
+	return func() tea.Msg {
+		deployment, err := c.GetDeployment(namespace, name)
+		if err != nil {
+			return detailFetchedMsg{key: key, err: err}
+		}
+		return detailFetchedMsg{key: key, obj: deployment}
+	}
+}
+
+// setDetailObject keeps the fetched object if its detail view is still open.
+// Failing to fetch it isn't worth an error, the cached copy is shown instead.
+func (m *model) setDetailObject(msg detailFetchedMsg) {
+	if msg.err == nil && msg.key == m.detail {
+		m.detailFull = msg.obj
+	}
+}
+
+// detailChrome is the number of lines of the screen taken by the detail view
+// help.
+const detailChrome = 2
+
+// handleDetailKey scrolls the detail view, toggles wrapping its long lines on
+// w and showing the hidden annotations on a, and closes it on esc, q or d.
+func (m *model) handleDetailKey(key tea.KeyMsg) tea.Cmd {
+	page := max(m.height-detailChrome, 1)
+	switch key.String() {
+	// The dashboard of the focused deployment is all there is to go back to
+	case "ctrl+c", "q":
+		if m.focus != "" {
+			return m.quit()
+		}
+		m.detail = ""
+	case "esc", "d":
+		if m.focus == "" {
+			m.detail = ""
+		}
+	case "w":
+		m.detailWrap = !m.detailWrap
+	case "a":
+		m.unfiltered = !m.unfiltered
+	case "up", "k":
+		m.detailTop--
+	case "down", "j":
+		m.detailTop++
+	case "pgup":
+		m.detailTop -= page
+	case "pgdown", " ":
+		m.detailTop += page
+	case "home", "g":
+		m.detailTop = 0
+	}
+	m.detailTop = min(m.detailTop, len(m.detailLines())-page)
+	m.detailTop = max(m.detailTop, 0)
+	return nil
+}
+
+// object returns the object of the active resource stored under key.
+func (m model) object(key string) (meta_v1.Object, bool) {
+	var obj meta_v1.Object
+	var ok bool
+	switch m.resource {
+	case podsResource:
+		obj, ok = m.pods[key]
+	case nodesResource:
+		obj, ok = m.nodes[key]
+	case cronJobsResource:
+		obj, ok = m.cronJobs[key]
+	case jobsResource:
+		obj, ok = m.jobs[key]
+	case ingressesResource:
+		obj, ok = m.ingresses[key]
+	case servicesResource:
+		obj, ok = m.services[key]
+	case customResource:
+		obj, ok = m.custom[key]
+	default:
+		obj, ok = m.deployments[key]
+	}
+	return obj, ok
+}
+
+// detailView renders the lines of the detail view fitting the terminal,
+// starting at the scroll position.
+func (m model) detailView() string {
+	lines := m.detailLines()
+	if m.height > detailChrome {
+		top := min(m.detailTop, max(len(lines)-(m.height-detailChrome), 0))
+		lines = lines[top:min(top+m.height-detailChrome, len(lines))]
+	}
+	return strings.Join(lines, "\n") + "\n\nPress esc to go back, arrows to scroll, w to wrap or truncate long lines."
+}
+
+// detailLines returns the lines of the detail view, long ones wrapped or
+// truncated to the terminal width.
+func (m model) detailLines() []string {
+	lines := strings.Split(strings.TrimSuffix(m.detailContent(), "\n"), "\n")
+	if m.width <= 0 {
+		return lines
+	}
+
+	fitted := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if m.detailWrap {
+			wrapped := lipgloss.NewStyle().Width(m.width).Render(line)
+			fitted = append(fitted, strings.Split(wrapped, "\n")...)
+		} else {
+			// Unlike truncate, this keeps the color escapes intact
+			fitted = append(fitted, ansi.Truncate(line, m.width, ellipsis))
+		}
+	}
+	return fitted
+}
+
+// detailContent renders the details of the object under m.detail.
+func (m model) detailContent() string {
+	var builder strings.Builder
+
+	obj, ok := m.object(m.detail)
+	if !ok {
+		fmt.Fprintf(&builder, "%s %s no longer exists.\n", m.resource, m.detail)
+	} else {
+		// The metadata of the fetched object is complete, the cache has the
+		// latest status
+		filter := m.annotations
+		if m.unfiltered {
+			filter = annotationFilter{}
+		}
+		if full := m.detailFull; full != nil && full.GetNamespace()+"/"+full.GetName() == m.detail {
+			builder.WriteString(metadataDetail(full, filter))
+		} else {
+			builder.WriteString(metadataDetail(obj, filter))
+		}
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			builder.WriteString(unhealthyDetail(o))
+			builder.WriteString(selectorDetail(o, m.deployments))
+			builder.WriteString(templateHashDetail(o, m.replicaSets))
+			builder.WriteString(strategyDetail(o))
+			builder.WriteString(rolloutDetail(o, m.width-2))
+			builder.WriteString(m.followDetail(m.detail))
+			builder.WriteString(historyDetail(o, m.history[m.detail]))
+			builder.WriteString(hpaDetail(deploymentHPAs(o, m.hpas)))
+			builder.WriteString(exposureDetail(deploymentServices(o, m.services)))
+			builder.WriteString(deploymentPodsDetail(deploymentPods(o, m.pods)))
+		case *networkingv1.Ingress:
+			builder.WriteString(ingressDetail(o))
+		case *corev1.Pod:
+			builder.WriteString(m.podDetail(o))
+		case *corev1.Service:
+			builder.WriteString(serviceDetail(o, m.slices))
+		case *corev1.Node:
+			builder.WriteString(drainDetail(o, m.pods))
+		case *unstructured.Unstructured:
+			builder.WriteString(customDetail(o))
+		}
+	}
+
+	return builder.String()
+}
+
+// metadataDetail renders the name, namespace, age, labels and annotations
+// shared by every object, leaving out the annotations hidden by filter.
+func metadataDetail(obj meta_v1.Object, filter annotationFilter) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Name:      %s\n", obj.GetName())
+	if namespace := obj.GetNamespace(); namespace != "" {
+		fmt.Fprintf(&builder, "Namespace: %s\n", namespace)
+	}
+	fmt.Fprintf(&builder, "Age:       %s\n", duration.HumanDuration(time.Since(obj.GetCreationTimestamp().Time)))
+	builder.WriteString("Labels:\n")
+	builder.WriteString(mapDetail(obj.GetLabels()))
+	annotations, hidden := filter.apply(obj.GetAnnotations())
+	builder.WriteString("Annotations:\n")
+	// The placeholder would be misleading when all of them are hidden
+	if len(annotations) > 0 || hidden == 0 {
+		builder.WriteString(mapDetail(annotations))
+	}
+	if hidden > 0 {
+		fmt.Fprintf(&builder, "  (%d hidden, press a to show every annotation)\n", hidden)
+	}
+	return builder.String()
+}
+
+// mapDetail renders the entries of values as indented key=value lines, sorted
+// by key.
+func mapDetail(values map[string]string) string {
+	if len(values) == 0 {
+		return "  " + placeholder + "\n"
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&builder, "  %s=%s\n", k, values[k])
+	}
+	return builder.String()
+}
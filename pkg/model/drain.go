@@ -0,0 +1,54 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// drainCandidates returns the keys of the pods running on the node nodeName
+// which draining it would evict, sorted. Like kubectl drain it passes over
+// the pods of DaemonSets, which would only be scheduled back, and mirror
+// pods, returning how many of each it skipped.
+func drainCandidates(nodeName string, pods map[string]*corev1.Pod) (keys []string, daemonSetPods, mirrorPods int) {
+	for key, pod := range pods {
+		if pod.Spec.NodeName != nodeName {
+			continue
+		}
+		// The kubelet creates mirror pods for its static pods, evicting them
+		// does nothing
+		if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+			mirrorPods++
+			continue
+		}
+		if ref := meta_v1.GetControllerOf(pod); ref != nil && ref.Kind == "DaemonSet" {
+			daemonSetPods++
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, daemonSetPods, mirrorPods
+}
+
+// drainDetail renders the pods draining node would evict. Nothing is evicted,
+// it only previews a drain.
+func drainDetail(node *corev1.Node, pods map[string]*corev1.Pod) string {
+	keys, daemonSetPods, mirrorPods := drainCandidates(node.Name, pods)
+
+	var builder strings.Builder
+	builder.WriteString("Draining would evict:\n")
+	if len(keys) == 0 {
+		builder.WriteString("  " + placeholder + "\n")
+	}
+	for _, key := range keys {
+		fmt.Fprintf(&builder, "  %s %s\n", key, pods[key].Status.Phase)
+	}
+	if daemonSetPods > 0 || mirrorPods > 0 {
+		fmt.Fprintf(&builder, "  (skipping %d DaemonSet pod(s) and %d mirror pod(s))\n", daemonSetPods, mirrorPods)
+	}
+	return builder.String()
+}
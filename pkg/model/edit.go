@@ -0,0 +1,130 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/AClarkie/k8s-tui/pkg/controller"
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultEditor is used when $EDITOR is not set.
+const defaultEditor = "vi"
+
+// editDoneMsg reports that the editor opened by editDeployment has exited.
+type editDoneMsg struct {
+	path     string
+	original []byte
+	err      error
+}
+
+// editFetchedMsg carries the deployment fetched to be edited.
+type editFetchedMsg struct {
+	deployment *appsv1.Deployment
+	err        error
+}
+
+// editDeployment fetches the deployment under the cursor to edit it, rather
+// than editing the cached copy which may have been trimmed.
+func (m *model) editDeployment() tea.Cmd {
+	namespace, name, ok := m.currentDeployment()
+	if !ok {
+		return nil
+	}
+	c := m.controller
+	return func() tea.Msg {
+		deployment, err := c.GetDeployment(namespace, name)
+		return editFetchedMsg{deployment: deployment, err: err}
+	}
+}
+
+// openEditor writes the fetched deployment to a temporary file and opens it in
+// $EDITOR, suspending the TUI until the editor exits.
+func (m *model) openEditor(msg editFetchedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.showError(msg.err)
+		return nil
+	}
+	deployment := msg.deployment
+
+	original, err := encodeDeployment(deployment)
+	if err != nil {
+		m.showError(err)
+		return nil
+	}
+
+	f, err := os.CreateTemp("", "k8s-tui-*.yaml")
+	if err != nil {
+		m.showError(fmt.Errorf("failed to create file to edit, got err: %w", err))
+		return nil
+	}
+	defer f.Close()
+	if _, err := f.Write(original); err != nil {
+		os.Remove(f.Name())
+		m.showError(fmt.Errorf("failed to write file to edit, got err: %w", err))
+		return nil
+	}
+
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{defaultEditor}
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], f.Name())...)
+
+	path := f.Name()
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editDoneMsg{path: path, original: original, err: err}
+	})
+}
+
+// finishEdit updates the deployment from the edited file, unless the editor
+// failed or nothing was changed.
+func (m *model) finishEdit(msg editDoneMsg) tea.Cmd {
+	defer os.Remove(msg.path)
+
+	if msg.err != nil {
+		m.showError(fmt.Errorf("editor failed, got err: %w", msg.err))
+		return nil
+	}
+
+	edited, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.showError(fmt.Errorf("failed to read edited file, got err: %w", err))
+		return nil
+	}
+	if bytes.Equal(edited, msg.original) {
+		m.message = "Edit cancelled, no changes made."
+		return nil
+	}
+
+	deployment, err := controller.DecodeDeployment(edited)
+	if err != nil {
+		m.showError(err)
+		return nil
+	}
+
+	c := m.controller
+	return m.runOperation(func() error {
+		return c.UpdateDeployment(deployment)
+	})
+}
+
+// encodeDeployment renders deployment as YAML, leaving out the managed fields
+// which are noise when editing.
+func encodeDeployment(deployment *appsv1.Deployment) ([]byte, error) {
+	deployment = deployment.DeepCopy()
+	deployment.APIVersion = "apps/v1"
+	deployment.Kind = "Deployment"
+	deployment.ManagedFields = nil
+
+	data, err := yaml.Marshal(deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deployment %s/%s, got err: %w", deployment.GetNamespace(), deployment.GetName(), err)
+	}
+	return data, nil
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// errorLogSize is how many errors the error log keeps.
+const errorLogSize = 50
+
+// errorEntry is an error and when it was recorded.
+type errorEntry struct {
+	at  time.Time
+	err error
+}
+
+// errorLog is a ring buffer of the most recent errors.
+type errorLog struct {
+	entries []errorEntry
+	next    int // where the next entry goes once the buffer is full
+}
+
+// add records err, overwriting the oldest entry once the log is full.
+func (l *errorLog) add(err error) {
+	entry := errorEntry{at: time.Now(), err: err}
+	if len(l.entries) < errorLogSize {
+		l.entries = append(l.entries, entry)
+		return
+	}
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % errorLogSize
+}
+
+// list returns the recorded errors, oldest first.
+func (l errorLog) list() []errorEntry {
+	return append(append([]errorEntry{}, l.entries[l.next:]...), l.entries[:l.next]...)
+}
+
+// String renders the recorded errors, oldest first, each preceded by the time
+// it was recorded.
+func (l errorLog) String() string {
+	entries := l.list()
+	if len(entries) == 0 {
+		return "No errors so far."
+	}
+	var builder strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&builder, "%s %v\n\n", e.at.Format(time.TimeOnly), e.err)
+	}
+	return builder.String()
+}
+
+// controllerErrorMsg carries an error reported by the controller.
+type controllerErrorMsg struct {
+	err error
+}
+
+// waitForControllerError blocks until the controller reports an error.
+func (m model) waitForControllerError() tea.Cmd {
+	errs := m.controller.Errors()
+	return func() tea.Msg {
+		return controllerErrorMsg{err: <-errs}
+	}
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/AClarkie/k8s-tui/pkg/controller"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// errorPaneChrome is the number of lines of the screen taken by the error pane
+// title and help.
+const errorPaneChrome = 3
+
+// showError records err in the error log and opens a scrollable pane
+// holding its full text.
+func (m *model) showError(err error) {
+	m.errorLog.add(err)
+	title, hint := describeError(err)
+	text := err.Error()
+	if hint != "" {
+		text += "\n\n" + hint
+	}
+	m.openErrorPane(title, text)
+}
+
+// describeError returns the title of the error pane showing err and a hint
+// at what to do about it, which is empty for errors of no known class.
+func describeError(err error) (title, hint string) {
+	switch {
+	case errors.Is(err, controller.ErrReadOnly):
+		return "⊘ Read-only", "Restart without -read-only to make changes."
+	case errors.Is(err, controller.ErrForbidden):
+		return "✗ Forbidden", "Your user isn't allowed to do this, check its RBAC roles or use -as."
+	case errors.Is(err, controller.ErrUnauthorized):
+		return "✗ Unauthorized", "The credentials were rejected, they may have expired."
+	case errors.Is(err, controller.ErrNotFound):
+		return "? Not found", "The object may have been deleted in the meantime."
+	case errors.Is(err, controller.ErrConflict):
+		return "⇄ Conflict", "The object changed in the meantime, try again on the latest version."
+	default:
+		return "Error", ""
+	}
+}
+
+// showErrorLog opens the error pane on the recorded errors, oldest first.
+func (m *model) showErrorLog() {
+	m.openErrorPane("Errors", m.errorLog.String())
+	// Start at the most recent error
+	m.errorPane.GotoBottom()
+}
+
+// openErrorPane opens a scrollable pane holding text under title.
+func (m *model) openErrorPane(title, text string) {
+	pane := viewport.New(m.width, m.height-errorPaneChrome)
+	pane.SetContent(lipgloss.NewStyle().Width(m.width).Render(text))
+	m.errorPane = &pane
+	m.errorTitle = title
+	m.errorText = text
+}
+
+// resizeErrorPane fits the open error pane to the terminal.
+func (m *model) resizeErrorPane() {
+	m.errorPane.Width = m.width
+	m.errorPane.Height = m.height - errorPaneChrome
+	m.errorPane.SetContent(lipgloss.NewStyle().Width(m.width).Render(m.errorText))
+}
+
+// handleErrorPaneKey scrolls the open error pane, or closes it on esc, enter
+// or q.
+func (m *model) handleErrorPaneKey(key tea.KeyMsg) tea.Cmd {
+	switch key.String() {
+	case "esc", "enter", "q":
+		m.errorPane = nil
+		return nil
+	}
+
+	var cmd tea.Cmd
+	*m.errorPane, cmd = m.errorPane.Update(key)
+	return cmd
+}
+
+func (m model) errorPaneView() string {
+	return m.errorTitle + "\n\n" + m.errorPane.View() + "\nPress esc to close, arrows to scroll."
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startFilter prompts for the text rows of the active tab have to contain to
+// be listed.
+func (m *model) startFilter() {
+	m.prompt = newPrompt("Filter: ", func(m *model, value string) tea.Cmd {
+		m.setFilter(value)
+		return nil
+	})
+	m.prompt.input.SetValue(m.filters[m.resource])
+}
+
+// setFilter lists only the rows of the active tab containing filter, or every
+// row when empty. The other tabs keep their own filters.
+func (m *model) setFilter(filter string) {
+	m.filters[m.resource] = filter
+	m.cursor = 0
+	m.refreshChoices()
+}
+
+// filterKeys returns the keys containing filter.
+func filterKeys(keys []string, filter string) []string {
+	if filter == "" {
+		return keys
+	}
+	filtered := keys[:0]
+	for _, key := range keys {
+		if strings.Contains(key, filter) {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pods returns the snapshot message of pods stored under keys.
+func pods(keys ...string) podMsg {
+	msg := make(podMsg, len(keys))
+	for _, key := range keys {
+		namespace, name := splitKey(key)
+		msg[key] = &corev1.Pod{ObjectMeta: meta_v1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	return msg
+}
+
+// filter returns the messages of typing text at the filter prompt.
+func filter(text string) []tea.Msg {
+	return []tea.Msg{key("/"), key(text), key("enter")}
+}
+
+func TestFiltersPerTab(t *testing.T) {
+	tests := []struct {
+		name string
+		msgs []tea.Msg
+		// The active tab, and the filter and rows of each tab once the messages are handled
+		wantResource         resource
+		wantDeploymentFilter string
+		wantDeployments      []string
+		wantPodFilter        string
+		wantPods             []string
+	}{
+		{
+			name:            "unfiltered",
+			wantDeployments: []string{"a/api", "a/web", "b/web"},
+			wantPods:        []string{"a/api-1", "a/db-1", "b/web-1"},
+		},
+		{
+			name:                 "filtering one tab",
+			msgs:                 filter("web"),
+			wantDeploymentFilter: "web",
+			wantDeployments:      []string{"a/web", "b/web"},
+			wantPods:             []string{"a/api-1", "a/db-1", "b/web-1"},
+		},
+		{
+			name:                 "distinct filters",
+			wantResource:         podsResource,
+			msgs:                 slices.Concat(filter("web"), []tea.Msg{key("tab")}, filter("db")),
+			wantDeploymentFilter: "web",
+			wantDeployments:      []string{"a/web", "b/web"},
+			wantPodFilter:        "db",
+			wantPods:             []string{"a/db-1"},
+		},
+		{
+			name:                 "switching back and forth",
+			msgs:                 slices.Concat(filter("web"), []tea.Msg{key("tab")}, filter("db"), []tea.Msg{key("shift+tab")}),
+			wantDeploymentFilter: "web",
+			wantDeployments:      []string{"a/web", "b/web"},
+			wantPodFilter:        "db",
+			wantPods:             []string{"a/db-1"},
+		},
+		{
+			name:                 "esc clears only the active tab",
+			wantResource:         podsResource,
+			msgs:                 slices.Concat(filter("web"), []tea.Msg{key("tab")}, filter("db"), []tea.Msg{key("esc")}),
+			wantDeploymentFilter: "web",
+			wantDeployments:      []string{"a/web", "b/web"},
+			wantPods:             []string{"a/api-1", "a/db-1", "b/web-1"},
+		},
+		{
+			name:                 "prompt prefilled with the tab's filter",
+			wantResource:         podsResource,
+			msgs:                 slices.Concat(filter("web"), []tea.Msg{key("tab"), key("/"), key("a"), key("enter")}),
+			wantDeploymentFilter: "web",
+			wantDeployments:      []string{"a/web", "b/web"},
+			wantPodFilter:        "a",
+			wantPods:             []string{"a/api-1", "a/db-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m tea.Model = newTestModel(t)
+			msgs := slices.Concat([]tea.Msg{
+				deployments("a/api", "a/web", "b/web"),
+				pods("a/api-1", "a/db-1", "b/web-1"),
+			}, tt.msgs)
+			for _, msg := range msgs {
+				m, _ = m.Update(msg)
+			}
+			got := m.(model)
+
+			if got.resource != tt.wantResource {
+				t.Errorf("resource = %v, want %v", got.resource, tt.wantResource)
+			}
+			if f := got.filters[deploymentsResource]; f != tt.wantDeploymentFilter {
+				t.Errorf("deployments filter = %q, want %q", f, tt.wantDeploymentFilter)
+			}
+			if f := got.filters[podsResource]; f != tt.wantPodFilter {
+				t.Errorf("pods filter = %q, want %q", f, tt.wantPodFilter)
+			}
+			if keys := got.filteredKeys(deploymentsResource); !slices.Equal(keys, tt.wantDeployments) {
+				t.Errorf("deployments rows = %v, want %v", keys, tt.wantDeployments)
+			}
+			if keys := got.filteredKeys(podsResource); !slices.Equal(keys, tt.wantPods) {
+				t.Errorf("pods rows = %v, want %v", keys, tt.wantPods)
+			}
+		})
+	}
+}
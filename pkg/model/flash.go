@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// flashDuration is how long the rows of changed deployments stay highlighted.
+const flashDuration = 3 * time.Second
+
+// flashStyle highlights the rows of the deployments which have just changed.
+var flashStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("14"))
+
+// flashExpiredMsg is sent flashDuration after a row started flashing.
+type flashExpiredMsg time.Time
+
+// noteChanges flashes the rows of the deployments which have changed since the
+// last snapshot, going by their resource version. New deployments don't
+// flash, or every row would after changing namespace. The returned command
+// ends the flashes it started.
+func (m *model) noteChanges(deployments map[string]*appsv1.Deployment) tea.Cmd {
+	versions := make(map[string]string, len(deployments))
+	now := time.Now()
+	flashed := false
+	for key, d := range deployments {
+		versions[key] = d.GetResourceVersion()
+		if seen, ok := m.versions[key]; ok && seen != d.GetResourceVersion() {
+			m.flashes[key] = now
+			flashed = true
+		}
+	}
+	m.versions = versions
+
+	if !flashed {
+		return nil
+	}
+	return tea.Tick(flashDuration, func(t time.Time) tea.Msg {
+		return flashExpiredMsg(t)
+	})
+}
+
+// expireFlashes stops highlighting the rows which started flashing at least
+// flashDuration before at.
+func (m *model) expireFlashes(at time.Time) {
+	for key, since := range m.flashes {
+		if !since.Add(flashDuration).After(at) {
+			delete(m.flashes, key)
+		}
+	}
+}
+
+// flashing reports whether the row of the deployment stored under key is
+// highlighted.
+func (m model) flashing(key string) bool {
+	_, ok := m.flashes[key]
+	return ok
+}
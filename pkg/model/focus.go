@@ -0,0 +1,60 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// openFocus shows the details of the focused deployment as soon as it is the
+// only one listed, turning the detail view into a dashboard of it.
+func (m *model) openFocus() {
+	if m.focus == "" || m.detail != "" || m.resource != deploymentsResource || len(m.choices) != 1 {
+		return
+	}
+	m.detail = m.choices[0]
+	m.detailTop = 0
+}
+
+// deploymentPods returns the pods in the namespace of d matched by its
+// selector, sorted by name.
+func deploymentPods(d *appsv1.Deployment, pods map[string]*corev1.Pod) []*corev1.Pod {
+	selector, err := meta_v1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil || selector.Empty() {
+		return nil
+	}
+	var matched []*corev1.Pod
+	for _, pod := range pods {
+		if pod.Namespace == d.Namespace && selector.Matches(labels.Set(pod.Labels)) {
+			matched = append(matched, pod)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched
+}
+
+// deploymentPodsDetail renders the phase, ready containers and restarts of
+// each pod of the deployment.
+func deploymentPodsDetail(pods []*corev1.Pod) string {
+	var builder strings.Builder
+	builder.WriteString("Pods:\n")
+	if len(pods) == 0 {
+		builder.WriteString("  " + placeholder + "\n")
+	}
+	for _, pod := range pods {
+		ready, restarts := 0, int32(0)
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Ready {
+				ready++
+			}
+			restarts += status.RestartCount
+		}
+		fmt.Fprintf(&builder, "  %s %s %d/%d ready, %d restart(s)\n", pod.Name, pod.Status.Phase, ready, len(pod.Spec.Containers), restarts)
+	}
+	return builder.String()
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// noGroup is the group of deployments missing the group-by label.
+const noGroup = "(none)"
+
+// groupColors are the colors cycled through for group headers.
+var groupColors = []lipgloss.Color{"1", "2", "3", "4", "5", "6"}
+
+// groupByNamespace is the group-by key grouping the deployments by namespace
+// rather than by a label.
+const groupByNamespace = "namespace"
+
+// groupValue returns the group of d, the value of its label key or its
+// namespace.
+func groupValue(d *appsv1.Deployment, key string) string {
+	if d == nil {
+		return noGroup
+	}
+	if key == groupByNamespace {
+		return d.Namespace
+	}
+	if value, ok := d.Labels[key]; ok && value != "" {
+		return value
+	}
+	return noGroup
+}
+
+// groupDeployments orders the already sorted keys by their group, keeping the
+// order within each group. Deployments without the label come last.
+func groupDeployments(keys []string, deployments map[string]*appsv1.Deployment, key string) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		gi, gj := groupValue(deployments[keys[i]], key), groupValue(deployments[keys[j]], key)
+		if gi == gj {
+			return false
+		}
+		if gi == noGroup || gj == noGroup {
+			return gj == noGroup
+		}
+		return gi < gj
+	})
+}
+
+// groupHeader renders the header line of group, colored consistently so the
+// same group always gets the same color.
+func groupHeader(key, group string) string {
+	h := fnv.New32a()
+	h.Write([]byte(group))
+	color := groupColors[h.Sum32()%uint32(len(groupColors))]
+	return lipgloss.NewStyle().Bold(true).Foreground(color).Render(key + ": " + group)
+}
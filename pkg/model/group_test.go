@@ -0,0 +1,69 @@
+package model
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// withLabel returns d labelled key=value.
+func withLabel(d *appsv1.Deployment, key, value string) *appsv1.Deployment {
+	if d.Labels == nil {
+		d.Labels = make(map[string]string)
+	}
+	d.Labels[key] = value
+	return d
+}
+
+func TestGroupDeployments(t *testing.T) {
+	deployments := map[string]*appsv1.Deployment{
+		"a/api":    withLabel(newDeployment("a/api", 1, 1), "team", "payments"),
+		"a/web":    withLabel(newDeployment("a/web", 1, 1), "team", "frontend"),
+		"b/api":    newDeployment("b/api", 1, 1),
+		"b/worker": withLabel(newDeployment("b/worker", 1, 1), "team", "payments"),
+	}
+	keys := []string{"a/api", "a/web", "b/api", "b/worker"}
+	groupDeployments(keys, deployments, "team")
+
+	want := []string{"a/web", "a/api", "b/worker", "b/api"}
+	if !slices.Equal(keys, want) {
+		t.Errorf("groupDeployments() = %v, want %v", keys, want)
+	}
+}
+
+func TestGroupedRowsShareColumns(t *testing.T) {
+	m := newTestModel(t, WithGroupBy("team"))
+	m.deployments = map[string]*appsv1.Deployment{
+		"default/a-much-longer-name": withLabel(newDeployment("default/a-much-longer-name", 3, 3), "team", "payments"),
+		"default/web":                withLabel(newDeployment("default/web", 1, 1), "team", "frontend"),
+		"kube-system/dns":            newDeployment("kube-system/dns", 2, 1),
+	}
+	m.refreshChoices()
+
+	var buf bytes.Buffer
+	m.writeTable(&buf, true, true)
+	out := ansi.Strip(buf.String())
+
+	// The ready counts of every row start in the same column
+	column := -1
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.Contains(line, "[ ]") {
+			continue
+		}
+		i := strings.LastIndex(line, " ") + 1
+		if column == -1 {
+			column = i
+		} else if i != column {
+			t.Errorf("ready column of %q at %d, want %d\n%s", line, i, column, out)
+		}
+	}
+	for _, heading := range []string{"team: frontend", "team: payments", "team: (none)"} {
+		if !strings.Contains(out, heading+"\n") {
+			t.Errorf("the table lacks the heading %q\n%s", heading, out)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// deploymentHealthy reports whether every desired replica of d is ready and
+// none of its conditions report a failure.
+func deploymentHealthy(d *appsv1.Deployment) bool {
+	return explainUnhealthy(d) == ""
+}
+
+// explainUnhealthy returns why d is unhealthy in one line, e.g. "1/3 ready;
+// Progressing=False (ProgressDeadlineExceeded)", or an empty string when it
+// is healthy.
+func explainUnhealthy(d *appsv1.Deployment) string {
+	var reasons []string
+	if desired := desiredReplicas(d); d.Status.ReadyReplicas < desired {
+		reasons = append(reasons, fmt.Sprintf("%d/%d ready", d.Status.ReadyReplicas, desired))
+	}
+	for _, condition := range d.Status.Conditions {
+		failing := false
+		switch condition.Type {
+		case appsv1.DeploymentAvailable, appsv1.DeploymentProgressing:
+			failing = condition.Status == corev1.ConditionFalse
+		case appsv1.DeploymentReplicaFailure:
+			failing = condition.Status == corev1.ConditionTrue
+		}
+		if !failing {
+			continue
+		}
+		reason := fmt.Sprintf("%s=%s", condition.Type, condition.Status)
+		if condition.Reason != "" {
+			reason += " (" + condition.Reason + ")"
+		}
+		reasons = append(reasons, reason)
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// unhealthyDetail renders why d is unhealthy, nothing when it is healthy.
+func unhealthyDetail(d *appsv1.Deployment) string {
+	explanation := explainUnhealthy(d)
+	if explanation == "" {
+		return ""
+	}
+	return "Unhealthy: " + explanation + "\n"
+}
+
+// healthFilter restricts the deployment rows by health.
+type healthFilter int
+
+const (
+	showAll healthFilter = iota
+	showUnhealthy
+	showHealthy
+)
+
+func (f healthFilter) String() string {
+	switch f {
+	case showUnhealthy:
+		return "unhealthy only"
+	case showHealthy:
+		return "healthy only"
+	default:
+		return "all"
+	}
+}
+
+// next returns the health filter following f.
+func (f healthFilter) next() healthFilter {
+	return (f + 1) % 3
+}
+
+// filterHealth returns the keys of the deployments matching f.
+func filterHealth(keys []string, deployments map[string]*appsv1.Deployment, f healthFilter) []string {
+	if f == showAll {
+		return keys
+	}
+	filtered := keys[:0]
+	for _, key := range keys {
+		if deploymentHealthy(deployments[key]) == (f == showHealthy) {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered
+}
+
+// jumpUnhealthy moves the cursor to the next unhealthy deployment in
+// direction, 1 for down and -1 for up, wrapping around at the ends of the
+// list. The cursor stays put when no other row is unhealthy.
+func (m *model) jumpUnhealthy(direction int) {
+	if m.resource != deploymentsResource || len(m.choices) == 0 {
+		return
+	}
+	n := len(m.choices)
+	for step := 1; step <= n; step++ {
+		i := ((m.cursor+direction*step)%n + n) % n
+		if d := m.deployments[m.choices[i]]; d != nil && !deploymentHealthy(d) {
+			m.cursor = i
+			return
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// withConditions returns d with conditions appended to its status.
+func withConditions(d *appsv1.Deployment, conditions ...appsv1.DeploymentCondition) *appsv1.Deployment {
+	d.Status.Conditions = append(d.Status.Conditions, conditions...)
+	return d
+}
+
+func TestExplainUnhealthy(t *testing.T) {
+	available := appsv1.DeploymentCondition{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue, Reason: "MinimumReplicasAvailable"}
+	progressing := appsv1.DeploymentCondition{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable"}
+	stalled := appsv1.DeploymentCondition{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"}
+	replicaFailure := appsv1.DeploymentCondition{Type: appsv1.DeploymentReplicaFailure, Status: corev1.ConditionTrue, Reason: "FailedCreate"}
+
+	defaultReplicas := newDeployment("default/web", 0, 0)
+	defaultReplicas.Spec.Replicas = nil
+
+	tests := []struct {
+		name string
+		d    *appsv1.Deployment
+		want string
+	}{
+		{"healthy", withConditions(newDeployment("default/web", 3, 3), available, progressing), ""},
+		{"scaled to zero", newDeployment("default/web", 0, 0), ""},
+		{"replica shortfall", newDeployment("default/web", 3, 1), "1/3 ready"},
+		// Without replicas in the spec one is wanted
+		{"default replicas", defaultReplicas, "0/1 ready"},
+		{"progressing false", withConditions(newDeployment("default/web", 3, 3), stalled), "Progressing=False (ProgressDeadlineExceeded)"},
+		{"replica failure", withConditions(newDeployment("default/web", 3, 3), available, replicaFailure), "ReplicaFailure=True (FailedCreate)"},
+		{
+			"condition without a reason",
+			withConditions(newDeployment("default/web", 1, 1), appsv1.DeploymentCondition{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse}),
+			"Available=False",
+		},
+		{
+			"everything at once",
+			withConditions(newDeployment("default/web", 3, 1), stalled, replicaFailure),
+			"1/3 ready; Progressing=False (ProgressDeadlineExceeded); ReplicaFailure=True (FailedCreate)",
+		},
+		// Unknown conditions and statuses aren't failures
+		{
+			"unknown status",
+			withConditions(newDeployment("default/web", 1, 1), appsv1.DeploymentCondition{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionUnknown}),
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := explainUnhealthy(tt.d); got != tt.want {
+				t.Errorf("explainUnhealthy() = %q, want %q", got, tt.want)
+			}
+			if got := deploymentHealthy(tt.d); got != (tt.want == "") {
+				t.Errorf("deploymentHealthy() = %v, want %v", got, tt.want == "")
+			}
+		})
+	}
+}
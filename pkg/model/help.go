@@ -0,0 +1,66 @@
+package model
+
+import "strings"
+
+// helpEntry describes what a key does in the help line.
+type helpEntry struct {
+	text    string
+	mutates bool // whether the key changes the cluster
+}
+
+// helpEntries are listed in the help line, in order.
+var helpEntries = []helpEntry{
+	{text: "tab to switch resources"},
+	{text: "s to sort"},
+	{text: "S to reverse"},
+	{text: "p to pin"},
+	{text: "P to pause"},
+	{text: "a to apply a file", mutates: true},
+	{text: "d for details"},
+	{text: "t for resource usage"},
+	{text: "h for the revision history"},
+	{text: "e to edit", mutates: true},
+	{text: "R to restart", mutates: true},
+	{text: "+/- to scale up/down", mutates: true},
+	{text: "ctrl+d to delete", mutates: true},
+	{text: "i to set an image", mutates: true},
+	{text: "z/Z to scale to zero/restore", mutates: true},
+	{text: "u to change the update strategy", mutates: true},
+	{text: "C to cordon/uncordon a node", mutates: true},
+	{text: "L/A to change labels/annotations", mutates: true},
+	{text: "E for recent errors"},
+	{text: "Y to copy the name"},
+	{text: "c to copy a kubectl command"},
+	{text: "n to change namespace"},
+	{text: "/ to filter"},
+	{text: ": for commands"},
+	{text: "H to filter by health"},
+	{text: "]/[ to jump to the next/previous unhealthy one"},
+	{text: "f to jump to a name"},
+	{text: "</> to resize the columns"},
+	{text: "v to reveal the full name"},
+	{text: "T to toggle verbose ages"},
+	{text: "o to quit and print the row as JSON"},
+	{text: "q to quit"},
+}
+
+// mutatingKeys are the keys changing the cluster, which do nothing in
+// read-only mode.
+var mutatingKeys = map[string]bool{
+	"a": true, "e": true, "i": true, "u": true, "L": true, "A": true,
+	"R": true, "C": true, "ctrl+d": true, "+": true, "-": true, "z": true,
+	"Z": true,
+}
+
+// helpLine lists what the keys do, leaving out those changing the cluster in
+// read-only mode.
+func (m model) helpLine() string {
+	var texts []string
+	for _, e := range helpEntries {
+		if e.mutates && m.controller.ReadOnly() {
+			continue
+		}
+		texts = append(texts, e.text)
+	}
+	return "Press " + strings.Join(texts, ", ") + "."
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AClarkie/k8s-tui/pkg/controller"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// helpKeys returns the keys e documents, e.g. "z" and "Z" for "z/Z to scale
+// to zero/restore".
+func helpKeys(e helpEntry) []string {
+	keys, _, _ := strings.Cut(e.text, " ")
+	return strings.Split(keys, "/")
+}
+
+func TestHelpEntriesCoverMutatingKeys(t *testing.T) {
+	documented := make(map[string]bool)
+	for _, e := range helpEntries {
+		for _, key := range helpKeys(e) {
+			documented[key] = e.mutates
+		}
+	}
+	for key := range mutatingKeys {
+		mutates, ok := documented[key]
+		switch {
+		case !ok:
+			t.Errorf("the help line doesn't document %q", key)
+		case !mutates:
+			t.Errorf("the help line doesn't hide %q in read-only mode", key)
+		}
+	}
+	for key, mutates := range documented {
+		if mutates && !mutatingKeys[key] {
+			t.Errorf("%q is hidden in read-only mode but still works", key)
+		}
+	}
+}
+
+func TestHelpLineReadOnly(t *testing.T) {
+	m := newTestModel(t)
+	c := controller.NewController(fake.NewSimpleClientset(), controller.WithReadOnly(true))
+	readOnly, err := InitialModel(c)
+	if err != nil {
+		t.Fatalf("InitialModel() failed, got err: %v", err)
+	}
+
+	for _, e := range helpEntries {
+		if !strings.Contains(m.helpLine(), e.text) {
+			t.Errorf("helpLine() leaves out %q", e.text)
+		}
+		if got := strings.Contains(readOnly.helpLine(), e.text); got == e.mutates {
+			t.Errorf("helpLine() in read-only mode lists %q: %v, want %v", e.text, got, !e.mutates)
+		}
+	}
+}
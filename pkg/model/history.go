@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// replicaHistorySize is how many ready replica counts are kept per deployment.
+const replicaHistorySize = 40
+
+// sparkBlocks are the sparkline bars, from lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// replicaSample is the ready replica count of a deployment and when it was
+// seen.
+type replicaSample struct {
+	at    time.Time
+	ready int32
+}
+
+// replicaHistory is a ring buffer of the most recent ready replica counts of
+// a deployment.
+type replicaHistory struct {
+	samples []replicaSample
+	next    int // where the next sample goes once the buffer is full
+}
+
+// add records ready unless it is the count last recorded, overwriting the
+// oldest sample once the history is full.
+func (h *replicaHistory) add(ready int32) {
+	if len(h.samples) > 0 && h.latest().ready == ready {
+		return
+	}
+	sample := replicaSample{at: time.Now(), ready: ready}
+	if len(h.samples) < replicaHistorySize {
+		h.samples = append(h.samples, sample)
+		return
+	}
+	h.samples[h.next] = sample
+	h.next = (h.next + 1) % replicaHistorySize
+}
+
+// latest returns the most recent sample, the history must not be empty.
+func (h *replicaHistory) latest() replicaSample {
+	if len(h.samples) < replicaHistorySize {
+		return h.samples[len(h.samples)-1]
+	}
+	return h.samples[(h.next+replicaHistorySize-1)%replicaHistorySize]
+}
+
+// list returns the recorded samples, oldest first.
+func (h *replicaHistory) list() []replicaSample {
+	return append(append([]replicaSample{}, h.samples[h.next:]...), h.samples[:h.next]...)
+}
+
+// recordReplicas adds the ready replica count of every deployment to its
+// history, and forgets the history of deleted deployments.
+func (m *model) recordReplicas() {
+	for key, d := range m.deployments {
+		h, ok := m.history[key]
+		if !ok {
+			h = &replicaHistory{}
+			m.history[key] = h
+		}
+		h.add(d.Status.ReadyReplicas)
+	}
+	for key := range m.history {
+		if _, ok := m.deployments[key]; !ok {
+			delete(m.history, key)
+		}
+	}
+}
+
+// historyDetail renders the ready replica history of d as a sparkline scaled
+// to the highest of its samples and desired replicas.
+func historyDetail(d *appsv1.Deployment, h *replicaHistory) string {
+	if h == nil || len(h.samples) == 0 {
+		return ""
+	}
+	samples := h.list()
+	max := desiredReplicas(d)
+	for _, s := range samples {
+		if s.ready > max {
+			max = s.ready
+		}
+	}
+	return fmt.Sprintf("Ready history: %s (%d change(s) over %s)\n",
+		sparkline(samples, max), len(samples)-1, duration.HumanDuration(time.Since(samples[0].at)))
+}
+
+// sparkline renders one bar per sample, the tallest standing for max.
+func sparkline(samples []replicaSample, max int32) string {
+	var builder strings.Builder
+	for _, s := range samples {
+		i := 0
+		if max > 0 {
+			i = int(s.ready) * (len(sparkBlocks) - 1) / int(max)
+		}
+		builder.WriteRune(sparkBlocks[i])
+	}
+	return builder.String()
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+type hpaMsg map[string]*autoscalingv2.HorizontalPodAutoscaler
+
+func (m model) checkHPAs() tea.Cmd {
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return hpaMsg(m.controller.HPAs())
+	})
+}
+
+// deploymentHPAs returns the autoscalers whose scale target is d, sorted by
+// name.
+func deploymentHPAs(d *appsv1.Deployment, hpas map[string]*autoscalingv2.HorizontalPodAutoscaler) []*autoscalingv2.HorizontalPodAutoscaler {
+	var matching []*autoscalingv2.HorizontalPodAutoscaler
+	for _, hpa := range hpas {
+		target := hpa.Spec.ScaleTargetRef
+		if hpa.Namespace == d.Namespace && target.Kind == "Deployment" && target.Name == d.Name {
+			matching = append(matching, hpa)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Name < matching[j].Name
+	})
+	return matching
+}
+
+// hpaDetail renders the replica bounds and metrics of hpas. It is empty when
+// there are none.
+func hpaDetail(hpas []*autoscalingv2.HorizontalPodAutoscaler) string {
+	if len(hpas) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Autoscalers:\n")
+	for _, hpa := range hpas {
+		minReplicas := int32(1)
+		if hpa.Spec.MinReplicas != nil {
+			minReplicas = *hpa.Spec.MinReplicas
+		}
+		fmt.Fprintf(&builder, "  %s: %d replicas (min %d, max %d, desired %d)\n",
+			hpa.Name, hpa.Status.CurrentReplicas, minReplicas, hpa.Spec.MaxReplicas, hpa.Status.DesiredReplicas)
+		for i, metric := range hpa.Spec.Metrics {
+			current := placeholder
+			if i < len(hpa.Status.CurrentMetrics) {
+				current = metricValue(hpa.Status.CurrentMetrics[i])
+			}
+			fmt.Fprintf(&builder, "    %s: %s / %s\n", metricName(metric), current, metricTarget(metric))
+		}
+	}
+	return builder.String()
+}
+
+// metricName names the quantity measured by metric, such as the resource or
+// the custom metric name.
+func metricName(metric autoscalingv2.MetricSpec) string {
+	switch {
+	case metric.Resource != nil:
+		return string(metric.Resource.Name)
+	case metric.ContainerResource != nil:
+		return fmt.Sprintf("%s (%s)", metric.ContainerResource.Name, metric.ContainerResource.Container)
+	case metric.Pods != nil:
+		return metric.Pods.Metric.Name
+	case metric.Object != nil:
+		return metric.Object.Metric.Name
+	case metric.External != nil:
+		return metric.External.Metric.Name
+	}
+	return string(metric.Type)
+}
+
+// metricTarget renders the target of metric.
+func metricTarget(metric autoscalingv2.MetricSpec) string {
+	var target autoscalingv2.MetricTarget
+	switch {
+	case metric.Resource != nil:
+		target = metric.Resource.Target
+	case metric.ContainerResource != nil:
+		target = metric.ContainerResource.Target
+	case metric.Pods != nil:
+		target = metric.Pods.Target
+	case metric.Object != nil:
+		target = metric.Object.Target
+	case metric.External != nil:
+		target = metric.External.Target
+	}
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return target.AverageValue.String()
+	case target.Value != nil:
+		return target.Value.String()
+	}
+	return placeholder
+}
+
+// metricValue renders the current value of metric.
+func metricValue(metric autoscalingv2.MetricStatus) string {
+	var current autoscalingv2.MetricValueStatus
+	switch {
+	case metric.Resource != nil:
+		current = metric.Resource.Current
+	case metric.ContainerResource != nil:
+		current = metric.ContainerResource.Current
+	case metric.Pods != nil:
+		current = metric.Pods.Current
+	case metric.Object != nil:
+		current = metric.Object.Current
+	case metric.External != nil:
+		current = metric.External.Current
+	}
+	switch {
+	case current.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *current.AverageUtilization)
+	case current.AverageValue != nil:
+		return current.AverageValue.String()
+	case current.Value != nil:
+		return current.Value.String()
+	}
+	return placeholder
+}
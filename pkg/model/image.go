@@ -0,0 +1,53 @@
+package model
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// setImage changes the image of a container of the deployment under the
+// cursor, asking which container first when it has several.
+func (m *model) setImage() tea.Cmd {
+	namespace, name, ok := m.currentDeployment()
+	if !ok {
+		return nil
+	}
+	deployment := m.deployments[namespace+"/"+name]
+	if deployment == nil {
+		return nil
+	}
+
+	containers := deployment.Spec.Template.Spec.Containers
+	switch len(containers) {
+	case 0:
+		return nil
+	case 1:
+		m.promptImage(namespace, name, containers[0])
+		return textinput.Blink
+	}
+
+	names := make([]string, len(containers))
+	for i, c := range containers {
+		names[i] = c.Name
+	}
+	m.picker = newPicker("Container", names, -1, func(m *model, i int) tea.Cmd {
+		m.promptImage(namespace, name, containers[i])
+		return textinput.Blink
+	})
+	return nil
+}
+
+// promptImage prompts for the new image of container, starting from its
+// current one.
+func (m *model) promptImage(namespace, name string, container corev1.Container) {
+	m.prompt = newPrompt("Image for "+container.Name+": ", func(m *model, image string) tea.Cmd {
+		if image == "" || image == container.Image {
+			return nil
+		}
+		return m.followRollout(namespace, name, func() error {
+			return m.controller.SetImage(namespace, name, container.Name, image)
+		})
+	})
+	m.prompt.input.SetValue(container.Image)
+}
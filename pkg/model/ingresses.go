@@ -0,0 +1,108 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// anyHost is rendered for ingress rules matching every host.
+const anyHost = "*"
+
+type ingressMsg map[string]*networkingv1.Ingress
+
+func (m model) checkIngresses() tea.Cmd {
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return ingressMsg(m.controller.Ingresses())
+	})
+}
+
+// ingressRow appends the class, hosts and address columns of ingress to its
+// key.
+func ingressRow(key string, ingress *networkingv1.Ingress) string {
+	if ingress == nil {
+		return key
+	}
+
+	class := placeholder
+	if ingress.Spec.IngressClassName != nil {
+		class = *ingress.Spec.IngressClassName
+	}
+
+	var hosts []string
+	for _, rule := range ingress.Spec.Rules {
+		hosts = append(hosts, orAnyHost(rule.Host))
+	}
+
+	var addresses []string
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		addresses = append(addresses, lb.IP+lb.Hostname)
+	}
+
+	return fmt.Sprintf("%s\t%s\t%s\t%s", key, class, orPlaceholder(strings.Join(hosts, ",")), orPlaceholder(strings.Join(addresses, ",")))
+}
+
+// ingressDetail renders the rules, default backend and TLS settings of
+// ingress.
+func ingressDetail(ingress *networkingv1.Ingress) string {
+	var builder strings.Builder
+
+	builder.WriteString("Rules:\n")
+	if len(ingress.Spec.Rules) == 0 {
+		fmt.Fprintf(&builder, "  %s\n", placeholder)
+	}
+	for _, rule := range ingress.Spec.Rules {
+		fmt.Fprintf(&builder, "  %s\n", orAnyHost(rule.Host))
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			pathType := placeholder
+			if path.PathType != nil {
+				pathType = string(*path.PathType)
+			}
+			fmt.Fprintf(&builder, "    %s (%s) -> %s\n", orPlaceholder(path.Path), pathType, ingressBackend(path.Backend))
+		}
+	}
+
+	if backend := ingress.Spec.DefaultBackend; backend != nil {
+		fmt.Fprintf(&builder, "Default backend: %s\n", ingressBackend(*backend))
+	}
+
+	if len(ingress.Spec.TLS) > 0 {
+		builder.WriteString("TLS:\n")
+		for _, tls := range ingress.Spec.TLS {
+			fmt.Fprintf(&builder, "  %s -> %s\n", orPlaceholder(strings.Join(tls.Hosts, ",")), orPlaceholder(tls.SecretName))
+		}
+	}
+
+	return builder.String()
+}
+
+// ingressBackend renders the service and port, or the resource, traffic is
+// routed to.
+func ingressBackend(backend networkingv1.IngressBackend) string {
+	if service := backend.Service; service != nil {
+		port := service.Port.Name
+		if port == "" {
+			port = fmt.Sprint(service.Port.Number)
+		}
+		return service.Name + ":" + port
+	}
+	if resource := backend.Resource; resource != nil {
+		return resource.Kind + "/" + resource.Name
+	}
+	return placeholder
+}
+
+// orAnyHost returns host, or anyHost when the host is empty.
+func orAnyHost(host string) string {
+	if host == "" {
+		return anyHost
+	}
+	return host
+}
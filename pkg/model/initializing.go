@@ -0,0 +1,50 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// syncPollInterval is how often the controller is asked whether it has
+// synced while initializing.
+const syncPollInterval = 100 * time.Millisecond
+
+// syncedMsg is sent once the controller has completed its initial lists.
+type syncedMsg struct{}
+
+// syncProgressMsg reports the failed lists of the controller while it is
+// still syncing.
+type syncProgressMsg struct {
+	failures int
+	err      error
+}
+
+// waitForSync polls the controller until it has synced.
+func (m model) waitForSync() tea.Cmd {
+	c := m.controller
+	return tea.Tick(syncPollInterval, func(time.Time) tea.Msg {
+		if c.HasSynced() {
+			return syncedMsg{}
+		}
+		failures, err := c.ListFailures()
+		return syncProgressMsg{failures: failures, err: err}
+	})
+}
+
+// initializingView is shown until the first snapshots arrive, along with the
+// attempt the lists are at once they have failed, so that a cluster which
+// can't be reached doesn't look like a frozen screen.
+func (m model) initializingView() string {
+	if m.failures == 0 || m.listErr == nil {
+		return "Initializing..."
+	}
+	status := fmt.Sprintf("Connecting to cluster... attempt %d: %s", m.failures+1, strings.ReplaceAll(m.listErr.Error(), "\n", "; "))
+	if m.width > 0 {
+		status = ansi.Truncate(status, m.width, ellipsis)
+	}
+	return "Initializing...\n\n" + status + "\n\nPress q to quit."
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+type jobMsg map[string]*batchv1.Job
+
+func (m model) checkJobs() tea.Cmd {
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return jobMsg(m.controller.Jobs())
+	})
+}
+
+// jobRow appends the completions, active and failed columns of job to its
+// key.
+func jobRow(key string, job *batchv1.Job) string {
+	if job == nil {
+		return key
+	}
+
+	// A job without completions set is done after its first success
+	completions := int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+
+	return fmt.Sprintf("%s\t%d/%d\t%d\t%d", key, job.Status.Succeeded, completions, job.Status.Active, job.Status.Failed)
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// jumpTimeout is how long the jump buffer is kept after the last key press.
+const jumpTimeout = time.Second
+
+// jumpResetMsg clears the jump buffer, unless keys were typed since it was
+// scheduled.
+type jumpResetMsg struct {
+	seq int
+}
+
+// startJump begins collecting a name prefix to move the cursor to.
+func (m *model) startJump() tea.Cmd {
+	m.jumping = true
+	m.jumpBuffer = ""
+	return m.scheduleJumpReset()
+}
+
+// scheduleJumpReset ends the jump once no key has been typed for jumpTimeout.
+func (m *model) scheduleJumpReset() tea.Cmd {
+	m.jumpSeq++
+	seq := m.jumpSeq
+	return tea.Tick(jumpTimeout, func(time.Time) tea.Msg {
+		return jumpResetMsg{seq: seq}
+	})
+}
+
+// resetJump ends the jump if msg is the latest scheduled reset.
+func (m *model) resetJump(msg jumpResetMsg) {
+	if msg.seq == m.jumpSeq {
+		m.stopJump()
+	}
+}
+
+func (m *model) stopJump() {
+	m.jumping = false
+	m.jumpBuffer = ""
+}
+
+// handleJumpKey extends the jump buffer with the typed characters and moves
+// the cursor to the first row whose name starts with it. Keys which aren't
+// part of the jump end it and are reported as not handled.
+func (m *model) handleJumpKey(key tea.KeyMsg) (bool, tea.Cmd) {
+	switch key.Type {
+	case tea.KeyRunes:
+		m.jumpBuffer += string(key.Runes)
+	case tea.KeyBackspace:
+		// Drop the whole last character, not just its last byte
+		_, size := utf8.DecodeLastRuneInString(m.jumpBuffer)
+		m.jumpBuffer = m.jumpBuffer[:len(m.jumpBuffer)-size]
+	case tea.KeyEsc, tea.KeyEnter:
+		m.stopJump()
+		return true, nil
+	default:
+		m.stopJump()
+		return false, nil
+	}
+
+	if i := jumpTarget(m.choices, m.jumpBuffer); i >= 0 {
+		m.cursor = i
+	}
+	return true, m.scheduleJumpReset()
+}
+
+// jumpTarget returns the index of the first key whose name starts with prefix,
+// or -1 if there is none.
+func jumpTarget(keys []string, prefix string) int {
+	if prefix == "" {
+		return -1
+	}
+	for i, key := range keys {
+		if _, name := splitKey(key); strings.HasPrefix(name, prefix) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestJumpBackspaceDropsWholeRunes(t *testing.T) {
+	m := newTestModel(t)
+	m.choices = []string{"default/api", "default/café", "default/cafè"}
+	m.startJump()
+
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("cafè")},
+		{Type: tea.KeyBackspace},
+	} {
+		if handled, _ := m.handleJumpKey(key); !handled {
+			t.Fatalf("handleJumpKey(%v) not handled", key)
+		}
+	}
+	if m.jumpBuffer != "caf" {
+		t.Errorf("jumpBuffer = %q, want %q", m.jumpBuffer, "caf")
+	}
+
+	m.handleJumpKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("è")})
+	if m.cursor != 2 {
+		t.Errorf("cursor = %d, want 2", m.cursor)
+	}
+}
+
+func TestJumpBackspaceOnEmptyBuffer(t *testing.T) {
+	m := newTestModel(t)
+	m.startJump()
+	m.handleJumpKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.jumpBuffer != "" {
+		t.Errorf("jumpBuffer = %q, want it empty", m.jumpBuffer)
+	}
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// kubectlVerbs are offered by the kubectl command picker.
+var kubectlVerbs = []string{"get", "describe", "edit", "delete"}
+
+// kubectlKind returns the kubectl name of the kind of r.
+func (r resource) kubectlKind() string {
+	switch r {
+	case podsResource:
+		return "pod"
+	case nodesResource:
+		return "node"
+	case cronJobsResource:
+		return "cronjob"
+	case jobsResource:
+		return "job"
+	case ingressesResource:
+		return "ingress"
+	case servicesResource:
+		return "service"
+	default:
+		return "deployment"
+	}
+}
+
+// kubectlKind returns the kubectl name of the kind of the active resource.
+func (m model) kubectlKind() string {
+	if gvr, ok := m.controller.CustomResource(); ok && m.resource == customResource {
+		return customKind(gvr)
+	}
+	return m.resource.kubectlKind()
+}
+
+// pickKubectlCommand asks for a verb and copies the kubectl command running it
+// against the row under the cursor.
+func (m *model) pickKubectlCommand() {
+	if m.cursor >= len(m.choices) {
+		return
+	}
+	key := m.choices[m.cursor]
+	m.picker = newPicker("Copy kubectl command", kubectlVerbs, -1, func(m *model, i int) tea.Cmd {
+		return m.copyText(kubectlCommand(m.kubectlFlags(), kubectlVerbs[i], m.kubectlKind(), key))
+	})
+}
+
+// kubectlFlags returns the global kubectl flags matching the cluster and
+// identity the controller uses.
+func (m model) kubectlFlags() []string {
+	var flags []string
+	if m.context != "" {
+		flags = append(flags, "--context", m.context)
+	}
+	if m.asUser != "" {
+		flags = append(flags, "--as", m.asUser)
+	}
+	for _, group := range m.asGroups {
+		flags = append(flags, "--as-group", group)
+	}
+	return flags
+}
+
+// kubectlCommand builds the kubectl command running verb against the object of
+// kind stored under key, preceded by the global flags.
+func kubectlCommand(flags []string, verb, kind, key string) string {
+	args := append([]string{"kubectl"}, flags...)
+	namespace, name := splitKey(key)
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+	args = append(args, verb, kind, name)
+	if verb == "get" {
+		args = append(args, "-o", "yaml")
+	}
+	return strings.Join(args, " ")
+}
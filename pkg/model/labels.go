@@ -0,0 +1,163 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// metadataPatch patches the labels or annotations of a deployment.
+type metadataPatch func(namespace, name string, add map[string]string, remove []string) error
+
+// selectedDeployments returns the keys of the selected deployments, or the
+// key of the one under the cursor when none are selected.
+func (m *model) selectedDeployments() []string {
+	if m.resource != deploymentsResource {
+		return nil
+	}
+	var keys []string
+	for _, key := range m.choices {
+		if _, ok := m.selected[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 && m.cursor < len(m.choices) {
+		keys = append(keys, m.choices[m.cursor])
+	}
+	return keys
+}
+
+// startLabelEdit prompts for the labels to add to or remove from the selected
+// deployments.
+func (m *model) startLabelEdit() {
+	m.startMetadataEdit("labels", m.controller.PatchLabels)
+}
+
+// startAnnotationEdit prompts for the annotations to add to or remove from the
+// selected deployments.
+func (m *model) startAnnotationEdit() {
+	m.startMetadataEdit("annotations", m.controller.PatchAnnotations)
+}
+
+// startMetadataEdit prompts for comma separated key=value entries to add and
+// -key entries to remove, then patches every selected deployment with patch.
+func (m *model) startMetadataEdit(field string, patch metadataPatch) {
+	keys := m.selectedDeployments()
+	if len(keys) == 0 {
+		return
+	}
+	label := fmt.Sprintf("Change %s of %d deployment(s) (key=value, -key): ", field, len(keys))
+	m.prompt = newPrompt(label, func(m *model, value string) tea.Cmd {
+		if value == "" {
+			return nil
+		}
+		add, remove, err := parseMetadataChange(value)
+		if err != nil {
+			m.showError(err)
+			return nil
+		}
+
+		return m.startMetadataBatch(field, keys, func(namespace, name string) error {
+			return patch(namespace, name, add, remove)
+		})
+	})
+}
+
+// metadataBatch is the progress of patching the labels or annotations of
+// several deployments, one after the other.
+type metadataBatch struct {
+	field  string
+	keys   []string
+	patch  func(namespace, name string) error
+	done   int
+	failed []string
+	errs   []error
+}
+
+// metadataPatchedMsg reports that the next deployment of batch was patched,
+// or failed to be when err is set.
+type metadataPatchedMsg struct {
+	batch *metadataBatch
+	err   error
+}
+
+// startMetadataBatch marks a batch patching the field of the deployments
+// stored under keys as in flight and returns the command patching the first
+// one.
+func (m *model) startMetadataBatch(field string, keys []string, patch func(namespace, name string) error) tea.Cmd {
+	batch := &metadataBatch{field: field, keys: keys, patch: patch}
+	m.pending++
+	m.message = batch.progress()
+	return batch.next()
+}
+
+// next returns the command patching the next deployment of b.
+func (b *metadataBatch) next() tea.Cmd {
+	namespace, name := splitKey(b.keys[b.done])
+	return func() tea.Msg {
+		return metadataPatchedMsg{batch: b, err: b.patch(namespace, name)}
+	}
+}
+
+// progress renders how far b has got, e.g. "Patching labels: 2/5".
+func (b *metadataBatch) progress() string {
+	return fmt.Sprintf("Patching %s: %d/%d", b.field, b.done, len(b.keys))
+}
+
+// summary renders the outcome of b, e.g. "4/5 patched, 1 failed: default/web".
+func (b *metadataBatch) summary() string {
+	s := fmt.Sprintf("%d/%d patched", b.done-len(b.failed), len(b.keys))
+	if len(b.failed) > 0 {
+		s += fmt.Sprintf(", %d failed: %s", len(b.failed), strings.Join(b.failed, ", "))
+	}
+	return s
+}
+
+// handleMetadataPatched records the outcome of patching the next deployment
+// of a batch, then patches the one after it. Once the batch is over, its
+// summary is flashed in the footer, or shown along with every error when any
+// deployment failed to be patched.
+func (m *model) handleMetadataPatched(msg metadataPatchedMsg) tea.Cmd {
+	batch := msg.batch
+	if msg.err != nil {
+		batch.failed = append(batch.failed, batch.keys[batch.done])
+		batch.errs = append(batch.errs, msg.err)
+	}
+	batch.done++
+	if batch.done < len(batch.keys) {
+		m.message = batch.progress()
+		return batch.next()
+	}
+
+	m.pending--
+	m.message = ""
+	summary := fmt.Sprintf("Changed %s: %s", batch.field, batch.summary())
+	if len(batch.errs) > 0 {
+		m.showError(fmt.Errorf("%s\n\n%w", summary, errors.Join(batch.errs...)))
+		return nil
+	}
+	return m.flash(summary)
+}
+
+// parseMetadataChange splits comma separated key=value and -key entries into
+// the entries to add and the keys to remove.
+func parseMetadataChange(value string) (map[string]string, []string, error) {
+	add := make(map[string]string)
+	var remove []string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
+		case strings.HasPrefix(entry, "-"):
+			remove = append(remove, strings.TrimPrefix(entry, "-"))
+		case strings.Contains(entry, "="):
+			k, v, _ := strings.Cut(entry, "=")
+			add[k] = v
+		default:
+			return nil, nil, fmt.Errorf("invalid entry %q, expected key=value or -key", entry)
+		}
+	}
+	return add, remove, nil
+}
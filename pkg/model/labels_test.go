@@ -0,0 +1,95 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AClarkie/k8s-tui/pkg/controller"
+)
+
+func TestMetadataBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        []string
+		failing     []string
+		wantMessage string
+		wantError   string
+	}{
+		{
+			name:        "all patched",
+			keys:        []string{"a/api", "a/web", "b/web"},
+			wantMessage: "Changed labels: 3/3 patched",
+		},
+		{
+			name:      "some failed",
+			keys:      []string{"a/api", "a/web", "b/web", "c/db"},
+			failing:   []string{"a/web", "c/db"},
+			wantError: "Changed labels: 2/4 patched, 2 failed: a/web, c/db\n\npatching a/web failed\npatching c/db failed",
+		},
+		{
+			name:      "all failed",
+			keys:      []string{"a/api"},
+			failing:   []string{"a/api"},
+			wantError: "Changed labels: 0/1 patched, 1 failed: a/api\n\npatching a/api failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t)
+			var patched []string
+			patch := func(namespace, name string) error {
+				key := namespace + "/" + name
+				patched = append(patched, key)
+				if slices.Contains(tt.failing, key) {
+					return fmt.Errorf("patching %s failed", key)
+				}
+				return nil
+			}
+
+			cmd := m.startMetadataBatch("labels", tt.keys, patch)
+			for i := range tt.keys {
+				if m.pending != 1 {
+					t.Fatalf("pending = %d while patching, want 1", m.pending)
+				}
+				if want := fmt.Sprintf("Patching labels: %d/%d", i, len(tt.keys)); m.message != want {
+					t.Errorf("message = %q, want %q", m.message, want)
+				}
+				cmd = m.handleMetadataPatched(cmd().(metadataPatchedMsg))
+			}
+
+			if !slices.Equal(patched, tt.keys) {
+				t.Errorf("patched %v, want %v", patched, tt.keys)
+			}
+			if m.pending != 0 {
+				t.Errorf("pending = %d once done, want 0", m.pending)
+			}
+			if m.message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", m.message, tt.wantMessage)
+			}
+			if m.errorText != tt.wantError {
+				t.Errorf("error = %q, want %q", m.errorText, tt.wantError)
+			}
+			if (tt.wantError != "") != (cmd == nil) {
+				t.Errorf("returned command %v, want a flash only when nothing failed", cmd)
+			}
+		})
+	}
+}
+
+func TestMetadataBatchKeepsErrorClasses(t *testing.T) {
+	m := newTestModel(t)
+	cmd := m.startMetadataBatch("annotations", []string{"a/web", "b/web"}, func(namespace, name string) error {
+		return fmt.Errorf("failed to patch annotations of deployment %s/%s, got err: %w", namespace, name, controller.ErrForbidden)
+	})
+	for cmd != nil {
+		cmd = m.handleMetadataPatched(cmd().(metadataPatchedMsg))
+	}
+	if m.errorTitle != "✗ Forbidden" {
+		t.Errorf("error title = %q, want the forbidden one", m.errorTitle)
+	}
+	if !strings.HasPrefix(m.errorText, "Changed annotations: 0/2 patched, 2 failed") {
+		t.Errorf("error = %q, want the summary of two forbidden patches", m.errorText)
+	}
+}
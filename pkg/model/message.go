@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// flashTimeout is how long a flashed message stays in the footer.
+const flashTimeout = 3 * time.Second
+
+// clearMessageMsg clears the footer message, unless another message was
+// flashed since it was scheduled.
+type clearMessageMsg struct {
+	seq int
+}
+
+// flash shows text in the footer for flashTimeout.
+func (m *model) flash(text string) tea.Cmd {
+	m.message = text
+	m.messageSeq++
+	seq := m.messageSeq
+	return tea.Tick(flashTimeout, func(time.Time) tea.Msg {
+		return clearMessageMsg{seq: seq}
+	})
+}
+
+// clearMessage clears the footer message if msg is the latest scheduled
+// clear.
+func (m *model) clearMessage(msg clearMessageMsg) {
+	if msg.seq == m.messageSeq {
+		m.message = ""
+	}
+}
@@ -7,11 +7,20 @@ import (
 	"sync"
 	"time"
 
-	"text/tabwriter"
-
 	"github.com/AClarkie/k8s-tui/pkg/controller"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
 type state int
@@ -21,35 +30,230 @@ const (
 	ready
 )
 
+// resource identifies the kind of object shown in the list.
+type resource int
+
+const (
+	deploymentsResource resource = iota
+	podsResource
+	nodesResource
+	cronJobsResource
+	jobsResource
+	ingressesResource
+	servicesResource
+	// customResource is the resource set with the -gvr flag, only shown when
+	// there is one
+	customResource
+)
+
+// apiName returns the API resource name of r, e.g. cronjobs.
+func (r resource) apiName() string {
+	return strings.ToLower(r.String())
+}
+
+// resources lists the tabs in the order they are cycled through.
+var resources = []resource{deploymentsResource, podsResource, nodesResource, cronJobsResource, jobsResource, ingressesResource, servicesResource}
+
+func (r resource) String() string {
+	switch r {
+	case podsResource:
+		return "Pods"
+	case nodesResource:
+		return "Nodes"
+	case cronJobsResource:
+		return "CronJobs"
+	case jobsResource:
+		return "Jobs"
+	case ingressesResource:
+		return "Ingresses"
+	case servicesResource:
+		return "Services"
+	case customResource:
+		return "Custom"
+	default:
+		return "Deployments"
+	}
+}
+
+// parseResource returns the resource whose tab is called name, ignoring case.
+func parseResource(name string) (resource, error) {
+	names := make([]string, len(resources))
+	for i, r := range resources {
+		if strings.EqualFold(r.String(), name) {
+			return r, nil
+		}
+		names[i] = strings.ToLower(r.String())
+	}
+	return 0, fmt.Errorf("resource %q is not watched, expected one of %s", name, strings.Join(names, ", "))
+}
+
 type model struct {
 	choices     []string // items on the to-do list
 	choiceMutex *sync.Mutex
-	cursor      int              // which to-do list item our cursor is pointing at
-	selected    map[int]struct{} // which to-do items are selected
+	cursor      int                 // which to-do list item our cursor is pointing at
+	selected    map[string]struct{} // keys of the selected rows
 	controller  *controller.Controller
 	state       state
+	resource    resource // which resource tab is active
+	deployments map[string]*appsv1.Deployment
+	pods        map[string]*corev1.Pod
+	nodes       map[string]*corev1.Node
+	cronJobs    map[string]*batchv1.CronJob
+	jobs        map[string]*batchv1.Job
+	ingresses   map[string]*networkingv1.Ingress
+	services    map[string]*corev1.Service
+	slices      map[string]*discoveryv1.EndpointSlice // endpoints of the services
+	// Autoscalers and replica sets are shown in the deployment details
+	hpas        map[string]*autoscalingv2.HorizontalPodAutoscaler
+	replicaSets map[string]*appsv1.ReplicaSet
+	pending     int             // number of imperative operations in flight
+	confirm     *confirmation   // question awaiting an answer, if any
+	message     string          // result of the last operation
+	messageSeq  int             // identifies the latest flashed message
+	sortMode    sortMode        // order of the deployment rows
+	sortDesc    bool            // whether the deployment rows are in descending order
+	paused      bool            // whether refreshes are ignored
+	prompt      *prompt         // text input awaiting submission, if any
+	errorPane   *viewport.Model // full text of the last error, if open
+	errorText   string          // the error shown in errorPane
+	errorTitle  string          // the title of errorPane
+	errorLog    errorLog        // the most recent errors
+	width       int             // terminal width
+	height      int             // terminal height
+	columns     []column        // columns of the deployment table
+	jumping     bool            // whether typed keys jump to a name
+	jumpBuffer  string          // the name prefix typed so far
+	jumpSeq     int             // identifies the latest jump reset
+	picker      *picker         // popup list awaiting a choice, if any
+	namespaces  []string        // namespaces offered by the namespace picker
+	detail      string          // key of the row whose details are shown, if any
+	detailTop   int             // first line of the detail view shown
+	detailWrap  bool            // whether long detail lines are wrapped instead of truncated
+	detailFull  meta_v1.Object  // the detail object fetched from the API server, if any
+	groupBy     string          // label key the deployments are grouped by, if any
+	health      healthFilter    // health of the listed deployments
+	table       tableFormat     // layout of the resource tables
+	context     string          // kubeconfig context in use, if known
+	asUser      string          // user the controller impersonates, if any
+	asGroups    []string        // groups the controller impersonates
+	eventDriven bool            // whether snapshots are reloaded on changes instead of every second
+	cellWidth   int             // width the table cells are truncated to, zero to fit the terminal
+	revealKey   string          // key of the row whose full name is shown, if any
+	verboseAge  bool            // whether ages are shown in two units, e.g. 3d4h
+	outputWidth int             // width PrintOnce fits the table to, zero for no limit
+	refreshedAt time.Time       // when the deployments were last reloaded
+	timeFormat  string          // format of refreshedAt in the status bar, see timeLayouts
+	backlog     int             // keys waiting to be synced by the controller
+	backlogAt   time.Time       // when backlog went over backlogThreshold, zero if it is below
+	failures    int             // failed lists in a row while initializing
+	listErr     error           // the last of them
+	unfiltered  bool            // whether the detail view shows the hidden annotations too
+
+	// The text the listed rows of each tab contain
+	filters map[resource]string
+	// The recent ready replica counts of each deployment
+	history map[string]*replicaHistory
+	// The object to print once the program has finished, if any
+	output runtime.Object
+	// The name of the deployment whose dashboard is shown, if any
+	focus string
+	// The rollout followed in the detail view, if any
+	follow followedRollout
+	// The objects of the custom resource, if any
+	custom map[string]*unstructured.Unstructured
+	// The annotations hidden from the detail view
+	annotations annotationFilter
+	// The keys of the pinned deployments, listed first whatever the sort
+	// mode and filters
+	pins map[string]struct{}
+	// The resource version of each deployment as of the last snapshot
+	versions map[string]string
+	// When the rows of the changed deployments started flashing
+	flashes map[string]time.Time
+	// Whether the usage view is open, which time it is since the start, and
+	// the pod metrics it shows or why they couldn't be fetched
+	top      bool
+	topSeq   int
+	usage    map[string]*metricsv1beta1.PodMetrics
+	usageErr error
+	// The resource quotas shown under the namespace group headers
+	quotas map[string]*corev1.ResourceQuota
+	// Whether the certificate of the API server goes unverified
+	insecureTLS bool
 }
 
-func InitialModel(controller *controller.Controller) (model, error) {
+func InitialModel(controller *controller.Controller, opts ...Option) (model, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	columns, err := parseColumns(o.columns)
+	if err != nil {
+		return model{}, err
+	}
+
+	if err := validTimeFormat(o.timeFormat); err != nil {
+		return model{}, err
+	}
+
+	if o.resourceName != "" {
+		r, err := parseResource(o.resourceName)
+		if err != nil {
+			return model{}, err
+		}
+		o.resource = r
+	}
+
 	return model{
 		// Our to-do list is a grocery list
 		choices: []string{},
 
 		// A map which indicates which choices are selected. We're using
-		// the  map like a mathematical set. The keys refer to the indexes
-		// of the `choices` slice, above.
-		selected:    make(map[int]struct{}),
+		// the  map like a mathematical set. The keys are the keys of the
+		// selected rows, so selections survive the rows being reordered.
+		selected:    make(map[string]struct{}),
 		choiceMutex: &sync.Mutex{},
 
 		controller: controller,
+
+		// Assume a classic terminal until the real size is known
+		width:  80,
+		height: 24,
+
+		columns:     columns,
+		groupBy:     o.groupBy,
+		table:       o.table,
+		sortMode:    o.sort,
+		resource:    o.resource,
+		filters:     map[resource]string{o.resource: o.filter},
+		history:     make(map[string]*replicaHistory),
+		pins:        o.pins,
+		flashes:     make(map[string]time.Time),
+		focus:       o.focus,
+		verboseAge:  o.verboseAge,
+		outputWidth: o.width,
+		timeFormat:  o.timeFormat,
+		annotations: parseAnnotationFilter(o.hideAnnotations, o.showAnnotations),
+		context:     o.context,
+		asUser:      o.asUser,
+		asGroups:    o.asGroups,
+		eventDriven: o.eventDriven,
+		insecureTLS: o.insecureTLS,
 	}, nil
 }
 
 func (m model) Init() tea.Cmd {
-	for !m.controller.Informer.HasSynced() {
-		time.Sleep(100 * time.Millisecond)
+	return m.waitForSync()
+}
+
+// startRefreshing starts reloading the snapshots once the controller has
+// synced.
+func (m model) startRefreshing() tea.Cmd {
+	if m.eventDriven {
+		return tea.Batch(loadChanges, m.waitForControllerError(), m.tickClock())
 	}
-	return m.checkDeployments()
+	return tea.Batch(m.waitForControllerError(), m.checkDeployments(), m.checkPods(), m.checkNodes(), m.checkCronJobs(), m.checkJobs(), m.checkIngresses(), m.checkHPAs(), m.checkReplicaSets(), m.checkServices(), m.checkEndpointSlices(), m.checkQuotas(), m.checkCustom(), m.checkBacklog())
 }
 
 type deploymentMsg map[string]*appsv1.Deployment
@@ -57,15 +261,15 @@ type deploymentMsg map[string]*appsv1.Deployment
 func (m model) checkDeployments() tea.Cmd {
 	d := time.Second * 1
 	return tea.Tick(d, func(t time.Time) tea.Msg {
-		return deploymentMsg(m.controller.CurrentDeployments)
+		return deploymentMsg(m.controller.Deployments())
 	})
 }
 
-func convertToSliceAndSort(deploymentMap map[string]*appsv1.Deployment) []string {
-	keys := make([]string, len(deploymentMap))
+func convertToSliceAndSort[T any](objectMap map[string]T) []string {
+	keys := make([]string, len(objectMap))
 
 	i := 0
-	for k := range deploymentMap {
+	for k := range objectMap {
 		keys[i] = k
 		i++
 	}
@@ -76,8 +280,109 @@ func convertToSliceAndSort(deploymentMap map[string]*appsv1.Deployment) []string
 	return keys
 }
 
+// clusterScoped is rendered in the namespace column of cluster scoped objects.
+const clusterScoped = "-"
+
+// splitKey splits a namespace/name key. Cluster scoped objects are keyed by
+// their name alone and have an empty namespace.
+func splitKey(key string) (namespace, name string) {
+	if namespace, name, found := strings.Cut(key, "/"); found {
+		return namespace, name
+	}
+	return "", key
+}
+
 func splitTheStringAndAddTabs(s string) string {
-	return strings.ReplaceAll(s, "/", "\t")
+	namespace, rest := splitKey(s)
+	if namespace == "" {
+		namespace = clusterScoped
+	}
+	return namespace + "\t" + rest
+}
+
+// setChoices replaces the rows of the list, moving the cursor back to the top
+// when new rows have appeared and onto the last row when rows have gone.
+func (m *model) setChoices(newChoices []string) {
+	if len(m.choices) < len(newChoices) {
+		m.cursor = 0
+	}
+	if m.cursor >= len(newChoices) {
+		m.cursor = max(len(newChoices)-1, 0)
+	}
+	m.choices = newChoices
+}
+
+// loadSnapshots replaces every snapshot with the current one of the
+// controller. The returned command ends the flashes of the changed rows.
+func (m *model) loadSnapshots() tea.Cmd {
+	m.deployments = m.controller.Deployments()
+	m.refreshedAt = time.Now()
+	m.recordReplicas()
+	flash := m.noteChanges(m.deployments)
+	m.pods = m.controller.Pods()
+	m.nodes = m.controller.Nodes()
+	m.cronJobs = m.controller.CronJobs()
+	m.jobs = m.controller.Jobs()
+	m.ingresses = m.controller.Ingresses()
+	m.services = m.controller.Services()
+	m.slices = m.controller.EndpointSlices()
+	m.hpas = m.controller.HPAs()
+	m.replicaSets = m.controller.ReplicaSets()
+	m.quotas = m.controller.ResourceQuotas()
+	m.custom = m.controller.CustomResources()
+	return flash
+}
+
+// refreshChoices rebuilds the rows from the snapshot of the active resource.
+func (m *model) refreshChoices() {
+	keys := m.filteredKeys(m.resource)
+	if m.resource == deploymentsResource {
+		sortDeployments(keys, m.deployments, m.sortMode, m.sortDesc)
+		// Grouping is stable, the pins stay on top of their group
+		pinFirst(keys, m.pins)
+		if m.groupBy != "" {
+			groupDeployments(keys, m.deployments, m.groupBy)
+		}
+	}
+	m.setChoices(keys)
+	m.openFocus()
+}
+
+// filteredKeys returns the sorted keys of the snapshot of r which pass the
+// active filters.
+func (m model) filteredKeys(r resource) []string {
+	switch r {
+	case podsResource:
+		return filterKeys(convertToSliceAndSort(m.pods), m.filters[r])
+	case nodesResource:
+		return filterKeys(convertToSliceAndSort(m.nodes), m.filters[r])
+	case cronJobsResource:
+		return filterKeys(convertToSliceAndSort(m.cronJobs), m.filters[r])
+	case jobsResource:
+		return filterKeys(convertToSliceAndSort(m.jobs), m.filters[r])
+	case ingressesResource:
+		return filterKeys(convertToSliceAndSort(m.ingresses), m.filters[r])
+	case servicesResource:
+		return filterKeys(convertToSliceAndSort(m.services), m.filters[r])
+	case customResource:
+		return filterKeys(convertToSliceAndSort(m.custom), m.filters[r])
+	default:
+		keys := filterKeys(convertToSliceAndSort(m.deployments), m.filters[r])
+		// Pinned deployments are listed whatever the filters
+		return m.withPins(filterHealth(keys, m.deployments, m.health))
+	}
+}
+
+// switchResource makes the resource offset tabs away from the current one
+// active.
+func (m *model) switchResource(offset int) {
+	tabs := m.tabs()
+	i := (int(m.resource) + offset + len(tabs)) % len(tabs)
+	m.resource = tabs[i]
+	m.choices = nil
+	m.cursor = 0
+	m.selected = make(map[string]struct{})
+	m.refreshChoices()
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -88,23 +393,290 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case deploymentMsg:
 
 		m.state = ready
-		newChoices := convertToSliceAndSort(map[string]*appsv1.Deployment(msg))
-		if len(m.choices) < len(newChoices) {
-			m.cursor = 0
+		if m.paused {
+			return m, m.checkDeployments()
+		}
+		m.deployments = msg
+		m.refreshedAt = time.Now()
+		m.recordReplicas()
+		flash := m.noteChanges(msg)
+		if m.resource == deploymentsResource {
+			m.refreshChoices()
+		}
+
+		return m, tea.Batch(m.checkDeployments(), m.checkFollow(), flash)
+
+	case syncProgressMsg:
+
+		m.failures, m.listErr = msg.failures, msg.err
+		return m, m.waitForSync()
+
+	case syncedMsg:
+
+		return m, m.startRefreshing()
+
+	case changeMsg:
+
+		m.state = ready
+		var flash tea.Cmd
+		if !m.paused {
+			flash = m.loadSnapshots()
+			m.refreshChoices()
+		}
+		m.trackBacklog(m.controller.QueueDepth())
+
+		return m, tea.Batch(m.waitForChange(), m.checkFollow(), flash)
+
+	case flashExpiredMsg:
+
+		m.expireFlashes(time.Time(msg))
+
+	case clockMsg:
+
+		return m, m.tickClock()
+
+	case backlogMsg:
+
+		m.trackBacklog(int(msg))
+		return m, m.checkBacklog()
+
+	case podMsg:
+
+		m.state = ready
+		if m.paused {
+			return m, m.checkPods()
+		}
+		m.pods = msg
+		if m.resource == podsResource {
+			m.refreshChoices()
+		}
+
+		return m, m.checkPods()
+
+	case nodeMsg:
+
+		m.state = ready
+		if m.paused {
+			return m, m.checkNodes()
+		}
+		m.nodes = msg
+		if m.resource == nodesResource {
+			m.refreshChoices()
+		}
+
+		return m, m.checkNodes()
+
+	case cronJobMsg:
+
+		m.state = ready
+		if m.paused {
+			return m, m.checkCronJobs()
+		}
+		m.cronJobs = msg
+		if m.resource == cronJobsResource {
+			m.refreshChoices()
+		}
+
+		return m, m.checkCronJobs()
+
+	case jobMsg:
+
+		m.state = ready
+		if m.paused {
+			return m, m.checkJobs()
+		}
+		m.jobs = msg
+		if m.resource == jobsResource {
+			m.refreshChoices()
+		}
+
+		return m, m.checkJobs()
+
+	case ingressMsg:
+
+		m.state = ready
+		if m.paused {
+			return m, m.checkIngresses()
+		}
+		m.ingresses = msg
+		if m.resource == ingressesResource {
+			m.refreshChoices()
 		}
-		m.choices = newChoices
 
-		return m, m.checkDeployments()
+		return m, m.checkIngresses()
+
+	case serviceMsg:
+
+		m.state = ready
+		if m.paused {
+			return m, m.checkServices()
+		}
+		m.services = msg
+		if m.resource == servicesResource {
+			m.refreshChoices()
+		}
+
+		return m, m.checkServices()
+
+	case customMsg:
+
+		m.state = ready
+		if m.paused {
+			return m, m.checkCustom()
+		}
+		m.custom = msg
+		if m.resource == customResource {
+			m.refreshChoices()
+		}
+
+		return m, m.checkCustom()
+
+	case endpointSliceMsg:
+
+		if !m.paused {
+			m.slices = msg
+			// The endpoint counts are part of the service rows
+			if m.resource == servicesResource {
+				m.refreshChoices()
+			}
+		}
+
+		return m, m.checkEndpointSlices()
+
+	case hpaMsg:
+
+		if !m.paused {
+			m.hpas = msg
+		}
+
+		return m, m.checkHPAs()
+
+	case quotaMsg:
+
+		if !m.paused {
+			m.quotas = msg
+		}
+
+		return m, m.checkQuotas()
+
+	case replicaSetMsg:
+
+		if !m.paused {
+			m.replicaSets = msg
+		}
+
+		return m, m.checkReplicaSets()
+
+	case controllerErrorMsg:
+
+		m.errorLog.add(msg.err)
+		return m, m.waitForControllerError()
+
+	case rolloutStartedMsg:
+
+		m.startFollowing(msg)
+		return m, m.checkFollow()
+
+	case metadataPatchedMsg:
+
+		return m, m.handleMetadataPatched(msg)
+
+	case operationDoneMsg:
+
+		m.pending--
+		m.message = ""
+		if msg.err != nil {
+			m.showError(msg.err)
+		}
+
+	case namespacesMsg:
+
+		if msg.err != nil {
+			m.showError(msg.err)
+			return m, nil
+		}
+		m.namespaces = msg.namespaces
+		m.openNamespacePicker()
+
+	case editFetchedMsg:
+
+		return m, m.openEditor(msg)
+
+	case usageMsg:
+
+		return m, m.setUsage(msg)
+
+	case detailFetchedMsg:
+
+		m.setDetailObject(msg)
+
+	case editDoneMsg:
+
+		return m, m.finishEdit(msg)
+
+	case jumpResetMsg:
+
+		m.resetJump(msg)
+
+	case clearMessageMsg:
+
+		m.clearMessage(msg)
+
+	case tea.WindowSizeMsg:
+
+		m.width, m.height = msg.Width, msg.Height
+		if m.errorPane != nil {
+			m.resizeErrorPane()
+		}
 
 	// Is it a key press?
 	case tea.KeyMsg:
 
+		// Open panes, prompts and questions take precedence over every
+		// other key
+		if m.errorPane != nil {
+			return m, m.handleErrorPaneKey(msg)
+		}
+		if m.picker != nil {
+			return m, m.handlePickerKey(msg)
+		}
+		if m.detail != "" {
+			return m, m.handleDetailKey(msg)
+		}
+		if m.top {
+			return m, m.handleTopKey(msg)
+		}
+		if m.prompt != nil {
+			return m, m.handlePromptKey(msg)
+		}
+		if m.confirm != nil {
+			return m, m.handleConfirmation(msg.String())
+		}
+		if m.jumping {
+			if handled, cmd := m.handleJumpKey(msg); handled {
+				return m, cmd
+			}
+		}
+
+		// Keys changing the cluster do nothing in read-only mode, the controller
+		// refuses the changes anyway
+		if m.controller.ReadOnly() && mutatingKeys[msg.String()] {
+			return m, nil
+		}
+
 		// Cool, what was the actual key pressed?
 		switch msg.String() {
 
 		// These keys should exit the program.
 		case "ctrl+c", "q":
-			return m, tea.Quit
+			return m, m.quit()
+
+		// The "tab" and "shift+tab" keys cycle through the resource tabs
+		case "tab":
+			m.switchResource(1)
+
+		case "shift+tab":
+			m.switchResource(-1)
 
 		// The "up" and "k" keys move the cursor up
 		case "up", "k":
@@ -121,12 +693,180 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// The "enter" key and the spacebar (a literal space) toggle
 		// the selected state for the item that the cursor is pointing at.
 		case "enter", " ":
-			_, ok := m.selected[m.cursor]
+			if m.cursor >= len(m.choices) {
+				break
+			}
+			key := m.choices[m.cursor]
+			_, ok := m.selected[key]
 			if ok {
-				delete(m.selected, m.cursor)
+				delete(m.selected, key)
 			} else {
-				m.selected[m.cursor] = struct{}{}
+				m.selected[key] = struct{}{}
+			}
+
+		// The "s" key cycles through the sort modes
+		case "s":
+			m.sortMode = m.sortMode.next()
+			m.refreshChoices()
+
+		// The "S" key reverses the sort direction
+		case "S":
+			m.sortDesc = !m.sortDesc
+			m.refreshChoices()
+
+		// The "P" key freezes the list, refreshing it straight away when
+		// unpaused
+		case "P":
+			m.paused = !m.paused
+			if !m.paused {
+				flash := m.loadSnapshots()
+				m.refreshChoices()
+				return m, flash
 			}
+
+		// The "]" and "[" keys move the cursor to the next and previous
+		// unhealthy deployment
+		case "]":
+			m.jumpUnhealthy(1)
+
+		case "[":
+			m.jumpUnhealthy(-1)
+
+		// The "H" key cycles through showing all, only unhealthy and only
+		// healthy deployments
+		case "H":
+			m.health = m.health.next()
+			m.cursor = 0
+			m.refreshChoices()
+
+		// The "/" key filters the rows of the tab, "esc" clears its filter
+		case "/":
+			m.startFilter()
+			return m, textinput.Blink
+
+		case "esc":
+			m.setFilter("")
+
+		// The "f" key jumps to the first row starting with the typed name
+		case "f":
+			return m, m.startJump()
+
+		// The "a" key applies a deployment manifest from a file
+		case "a":
+			m.startApply()
+			return m, textinput.Blink
+
+		// The "n" key changes the namespace being watched
+		case "n":
+			return m, m.pickNamespace()
+
+		// The "d" key shows the details of the row under the cursor
+		case "d":
+			return m, m.openDetail()
+
+		// The "L" and "A" keys change the labels and annotations of the
+		// selected deployments
+		case "L":
+			m.startLabelEdit()
+			return m, textinput.Blink
+		case "A":
+			m.startAnnotationEdit()
+			return m, textinput.Blink
+
+		// The "E" key shows the most recent errors
+		case "E":
+			m.showErrorLog()
+
+		// The "Y" key copies the name of the row under the cursor
+		case "Y":
+			return m, m.copyName()
+
+		// The "i" key sets the image of a container of the deployment under
+		// the cursor
+		case "i":
+			return m, m.setImage()
+
+		// The "c" key copies a kubectl command for the row under the cursor
+		case "c":
+			m.pickKubectlCommand()
+
+		// The "e" key edits the deployment under the cursor in $EDITOR
+		case "e":
+			return m, m.editDeployment()
+
+		// The "ctrl+d" key deletes the deployment under the cursor
+		case "ctrl+d":
+			m.deleteDeployment()
+
+		// The "R" key restarts the deployment under the cursor and follows
+		// the rollout
+		case "R":
+			return m, m.restartDeployment()
+
+		// The "t" key shows the resource usage of the deployments
+		case "t":
+			return m, m.openTop()
+
+		// The "p" key pins the deployment under the cursor to the top
+		case "p":
+			m.togglePin()
+
+		// The "C" key cordons or uncordons the node under the cursor
+		case "C":
+			m.toggleCordon()
+
+		// The "+" and "-" keys scale the deployment under the cursor
+		case "+":
+			return m, m.scaleDeployment(1)
+
+		case "-":
+			return m, m.scaleDeployment(-1)
+
+		// The "z" key scales the deployment under the cursor to zero, and
+		// the "Z" key scales it back
+		case "z":
+			m.scaleToZero()
+
+		case "Z":
+			m.restoreReplicas()
+
+		// The "<" and ">" keys narrow and widen the table cells
+		case "<":
+			m.resizeCells(-cellWidthStep)
+
+		case ">":
+			m.resizeCells(cellWidthStep)
+
+		// The "u" key changes the update strategy of the deployment under the cursor
+		case "u":
+			m.editStrategy()
+
+		// The "o" key quits and prints the row under the cursor as JSON
+		case "o":
+			return m, m.selectAndQuit()
+
+		// The "T" key switches the ages between one and two units
+		case "T":
+			m.verboseAge = !m.verboseAge
+
+		// The ":" key opens the command palette
+		case ":":
+			return m, m.openPalette()
+
+		// The "h" key lists the revisions of the deployment under the cursor
+		case "h":
+			return m, m.showRevisions()
+
+		// The "v" key reveals the full name of the row under the cursor
+		case "v":
+			m.toggleReveal()
+		}
+
+	default:
+
+		// Such as the blinks of the prompt cursor
+		if m.prompt != nil {
+			return m, m.updatePrompt(msg)
 		}
 	}
 
@@ -139,41 +879,68 @@ func (m model) View() string {
 	m.choiceMutex.Lock()
 	defer m.choiceMutex.Unlock()
 	if m.state == initializing {
-		return "Initializing..."
+		return m.initializingView()
+	}
+	if m.errorPane != nil {
+		return m.errorPaneView()
+	}
+	if m.picker != nil {
+		return m.picker.View()
+	}
+	if m.detail != "" {
+		return m.detailView()
+	}
+	if m.top {
+		return m.topView()
 	}
 
 	var builder strings.Builder
-	writer := tabwriter.NewWriter(&builder, 0, 8, 1, '\t', tabwriter.AlignRight)
-
-	// The header
-	footer := "\t Namespace\tDeployment\t\tReady\n"
-	footer += "\t ---------\t----------\t\t-----"
-	fmt.Fprintln(writer, footer)
 
-	// Iterate over our choices
-	for i, choice := range m.choices {
-
-		// Is the cursor pointing at this choice?
-		cursor := " " // no cursor
-		if m.cursor == i {
-			cursor = ">" // cursor!
+	// The tab bar, counting the rows each tab would list
+	for _, r := range m.tabs() {
+		count := fmt.Sprint(len(m.filteredKeys(r)))
+		if m.controller.Denied(m.apiName(r)) {
+			count = "no access"
 		}
-
-		// Is this choice selected?
-		checked := " " // not selected
-		if _, ok := m.selected[i]; ok {
-			checked = "x" // selected!
+		if r == m.resource {
+			fmt.Fprintf(&builder, "[%s (%s)] ", m.tabName(r), count)
+		} else {
+			fmt.Fprintf(&builder, " %s (%s)  ", m.tabName(r), count)
 		}
+	}
+	builder.WriteString("\n\n")
 
-		// Split the string and add tabs
-		choice = splitTheStringAndAddTabs(choice)
+	writer := m.table.newWriter(&builder)
 
-		// Render the row
-		fmt.Fprintln(writer, fmt.Sprintf("%s [%s] \t %s", cursor, checked, choice))
+	// The table
+	if m.controller.Denied(m.apiName(m.resource)) {
+		fmt.Fprintf(writer, "No access, you aren't allowed to watch %s.\n", m.apiName(m.resource))
+	} else if len(m.choices) == 0 {
+		fmt.Fprintln(writer, m.emptyTableMessage())
+	} else {
+		m.writeTable(writer, true, true)
 	}
 
 	// The footer
-	fmt.Fprintln(writer, "Press q to quit.")
+	if m.resource == deploymentsResource {
+		fmt.Fprintln(writer, summarize(m.choices, m.deployments))
+	}
+	if status := m.statusBar(); status != "" {
+		fmt.Fprintln(writer, status)
+	}
+	if m.revealed() {
+		fmt.Fprintln(writer, "Full name: "+m.revealKey)
+	}
+	if m.message != "" {
+		fmt.Fprintln(writer, m.message)
+	}
+	if m.prompt != nil {
+		fmt.Fprintln(writer, m.prompt.input.View())
+	} else if m.confirm != nil {
+		fmt.Fprintf(writer, "%s (y/n)\n", m.confirm.prompt)
+	} else {
+		fmt.Fprintln(writer, m.helpLine())
+	}
 
 	// Flush the writer and build the string
 	writer.Flush()
@@ -0,0 +1,218 @@
+package model
+
+import (
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/AClarkie/k8s-tui/pkg/controller"
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newTestModel creates a model whose controller watches a fake clientset
+// and is never run.
+func newTestModel(t *testing.T, opts ...Option) model {
+	t.Helper()
+	c := controller.NewController(fake.NewSimpleClientset(), controller.WithLogOutput(io.Discard))
+	m, err := InitialModel(c, opts...)
+	if err != nil {
+		t.Fatalf("InitialModel() failed, got err: %v", err)
+	}
+	return m
+}
+
+func TestSplitKey(t *testing.T) {
+	tests := []struct {
+		key           string
+		wantNamespace string
+		wantName      string
+		wantCells     string
+	}{
+		{"default/web", "default", "web", "default\tweb"},
+		{"kube-system/coredns", "kube-system", "coredns", "kube-system\tcoredns"},
+		// Cluster scoped objects are keyed by their name alone
+		{"node-1", "", "node-1", "-\tnode-1"},
+		// Only the first slash separates the namespace
+		{"default/web\t1/3", "default", "web\t1/3", "default\tweb\t1/3"},
+	}
+	for _, tt := range tests {
+		namespace, name := splitKey(tt.key)
+		if namespace != tt.wantNamespace || name != tt.wantName {
+			t.Errorf("splitKey(%q) = %q, %q, want %q, %q", tt.key, namespace, name, tt.wantNamespace, tt.wantName)
+		}
+		if got := splitTheStringAndAddTabs(tt.key); got != tt.wantCells {
+			t.Errorf("splitTheStringAndAddTabs(%q) = %q, want %q", tt.key, got, tt.wantCells)
+		}
+	}
+}
+
+// key returns the message of pressing the key named like tea.KeyMsg.String.
+func key(name string) tea.KeyMsg {
+	switch name {
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "shift+tab":
+		return tea.KeyMsg{Type: tea.KeyShiftTab}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEscape}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+}
+
+// deployments returns the snapshot message of healthy deployments stored
+// under keys.
+func deployments(keys ...string) deploymentMsg {
+	msg := make(deploymentMsg, len(keys))
+	for _, key := range keys {
+		msg[key] = newDeployment(key, 1, 1)
+	}
+	return msg
+}
+
+// selectedRows returns the rows of m which are selected, in order.
+func selectedRows(m model) []string {
+	var rows []string
+	for _, choice := range m.choices {
+		if _, ok := m.selected[choice]; ok {
+			rows = append(rows, choice)
+		}
+	}
+	return rows
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name         string
+		msgs         []tea.Msg
+		wantState    state
+		wantResource resource
+		wantCursor   int
+		wantSelected []string
+	}{
+		{
+			name:      "initializing",
+			wantState: initializing,
+		},
+		{
+			name:      "first snapshot",
+			msgs:      []tea.Msg{deployments("a/api", "b/web")},
+			wantState: ready,
+		},
+		{
+			name:       "down",
+			msgs:       []tea.Msg{deployments("a/api", "a/web", "b/web"), key("down"), key("j")},
+			wantState:  ready,
+			wantCursor: 2,
+		},
+		{
+			name:       "down past the last row",
+			msgs:       []tea.Msg{deployments("a/api", "b/web"), key("down"), key("down"), key("j")},
+			wantState:  ready,
+			wantCursor: 1,
+		},
+		{
+			name:       "up",
+			msgs:       []tea.Msg{deployments("a/api", "a/web", "b/web"), key("down"), key("down"), key("up"), key("k")},
+			wantState:  ready,
+			wantCursor: 0,
+		},
+		{
+			name:       "up past the first row",
+			msgs:       []tea.Msg{deployments("a/api", "b/web"), key("up"), key("k")},
+			wantState:  ready,
+			wantCursor: 0,
+		},
+		{
+			name:         "select",
+			msgs:         []tea.Msg{deployments("a/api", "a/web", "b/web"), key("down"), key(" "), key("down"), key("enter")},
+			wantState:    ready,
+			wantCursor:   2,
+			wantSelected: []string{"a/web", "b/web"},
+		},
+		{
+			name:         "unselect",
+			msgs:         []tea.Msg{deployments("a/api", "b/web"), key(" "), key("down"), key(" "), key("up"), key("enter")},
+			wantState:    ready,
+			wantSelected: []string{"b/web"},
+		},
+		{
+			name:       "new rows move the cursor to the top",
+			msgs:       []tea.Msg{deployments("a/api", "b/web"), key("down"), deployments("a/api", "b/web", "c/web")},
+			wantState:  ready,
+			wantCursor: 0,
+		},
+		{
+			name:       "unchanged rows keep the cursor",
+			msgs:       []tea.Msg{deployments("a/api", "b/web"), key("down"), deployments("a/api", "b/web")},
+			wantState:  ready,
+			wantCursor: 1,
+		},
+		{
+			name:       "removed rows move the cursor onto the last row",
+			msgs:       []tea.Msg{deployments("a/api", "a/web", "b/web"), key("down"), key("down"), deployments("a/api", "a/web")},
+			wantState:  ready,
+			wantCursor: 1,
+		},
+		{
+			name:       "no rows left",
+			msgs:       []tea.Msg{deployments("a/api", "b/web"), key("down"), deployments()},
+			wantState:  ready,
+			wantCursor: 0,
+		},
+		{
+			name:         "selections follow reordered rows",
+			msgs:         []tea.Msg{deployments("b/api", "b/web"), key("down"), key(" "), deployments("a/new", "b/api", "b/web")},
+			wantState:    ready,
+			wantSelected: []string{"b/web"},
+		},
+		{
+			name:         "selections of removed rows are ignored",
+			msgs:         []tea.Msg{deployments("a/api", "b/web"), key(" "), deployments("b/web")},
+			wantState:    ready,
+			wantSelected: nil,
+		},
+		{
+			name:         "selecting without rows",
+			msgs:         []tea.Msg{deployments(), key(" "), deployments("a/api", "b/web")},
+			wantState:    ready,
+			wantSelected: nil,
+		},
+		{
+			name:         "switching tabs",
+			msgs:         []tea.Msg{deployments("a/api", "b/web"), key("down"), key(" "), key("tab")},
+			wantState:    ready,
+			wantResource: podsResource,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m tea.Model = newTestModel(t)
+			for _, msg := range tt.msgs {
+				m, _ = m.Update(msg)
+			}
+			got := m.(model)
+
+			if got.state != tt.wantState {
+				t.Errorf("state = %v, want %v", got.state, tt.wantState)
+			}
+			if got.resource != tt.wantResource {
+				t.Errorf("resource = %v, want %v", got.resource, tt.wantResource)
+			}
+			if got.cursor != tt.wantCursor {
+				t.Errorf("cursor = %d, want %d", got.cursor, tt.wantCursor)
+			}
+			if rows := selectedRows(got); !slices.Equal(rows, tt.wantSelected) {
+				t.Errorf("selected rows = %v, want %v", rows, tt.wantSelected)
+			}
+		})
+	}
+}
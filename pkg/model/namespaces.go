@@ -0,0 +1,52 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// allNamespaces is the picker entry for watching every namespace.
+const allNamespaces = "(all namespaces)"
+
+// namespacesMsg carries the namespaces fetched for the namespace picker.
+type namespacesMsg struct {
+	namespaces []string
+	err        error
+}
+
+// pickNamespace opens the namespace picker, fetching the namespaces the first
+// time it is opened.
+func (m *model) pickNamespace() tea.Cmd {
+	if m.namespaces != nil {
+		m.openNamespacePicker()
+		return nil
+	}
+
+	c := m.controller
+	return func() tea.Msg {
+		namespaces, err := c.ListNamespaces()
+		return namespacesMsg{namespaces: namespaces, err: err}
+	}
+}
+
+// openNamespacePicker shows the fetched namespaces, highlighting the one being
+// watched.
+func (m *model) openNamespacePicker() {
+	items := append([]string{allNamespaces}, m.namespaces...)
+
+	current := 0
+	watched := m.controller.Namespace()
+	for i, namespace := range m.namespaces {
+		if namespace == watched {
+			current = i + 1
+		}
+	}
+
+	m.picker = newPicker("Namespace", items, current, func(m *model, i int) tea.Cmd {
+		namespace := ""
+		if i > 0 {
+			namespace = m.namespaces[i-1]
+		}
+		m.controller.SetNamespace(namespace)
+		return nil
+	})
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nodeRoleLabelPrefix prefixes the labels naming the roles of a node.
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+type nodeMsg map[string]*corev1.Node
+
+func (m model) checkNodes() tea.Cmd {
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return nodeMsg(m.controller.Nodes())
+	})
+}
+
+// nodeRow renders the cells of node, which is keyed by its name.
+func nodeRow(key string, node *corev1.Node) string {
+	if node == nil {
+		return key
+	}
+	allocatable := node.Status.Allocatable
+	return fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+		key,
+		nodeStatus(node),
+		orPlaceholder(strings.Join(nodeRoles(node), ",")),
+		node.Status.NodeInfo.KubeletVersion,
+		allocatable.Cpu(),
+		allocatable.Memory(),
+	)
+}
+
+// nodeStatus returns Ready, NotReady or Unknown from the Ready condition of
+// node.
+func nodeStatus(node *corev1.Node) string {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != corev1.NodeReady {
+			continue
+		}
+		switch condition.Status {
+		case corev1.ConditionTrue:
+			return "Ready"
+		case corev1.ConditionFalse:
+			return "NotReady"
+		}
+	}
+	return "Unknown"
+}
+
+// nodeRoles returns the sorted roles of node, taken from its role labels.
+func nodeRoles(node *corev1.Node) []string {
+	var roles []string
+	for label, value := range node.Labels {
+		switch {
+		case strings.HasPrefix(label, nodeRoleLabelPrefix):
+			if role := strings.TrimPrefix(label, nodeRoleLabelPrefix); role != "" {
+				roles = append(roles, role)
+			}
+		case label == "kubernetes.io/role" && value != "":
+			roles = append(roles, value)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// cordonedBadge is appended to the rows of nodes new pods aren't scheduled
+// onto.
+var cordonedBadge = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("Cordoned")
+
+// toggleCordon asks for confirmation before cordoning the node under the
+// cursor, or uncordoning it when it is cordoned already.
+func (m *model) toggleCordon() {
+	if m.resource != nodesResource || m.cursor >= len(m.choices) {
+		return
+	}
+	name := m.choices[m.cursor]
+	node := m.nodes[name]
+	if node == nil {
+		return
+	}
+
+	verb, schedulable := "Cordon", false
+	if node.Spec.Unschedulable {
+		verb, schedulable = "Uncordon", true
+	}
+	m.confirm = &confirmation{
+		prompt: verb + " node " + name + "?",
+		onYes: func(m *model) tea.Cmd {
+			return m.runOperation(func() error {
+				return m.controller.SetSchedulable(name, schedulable)
+			})
+		},
+	}
+}
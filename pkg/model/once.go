@@ -0,0 +1,47 @@
+package model
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/AClarkie/k8s-tui/pkg/controller"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// PrintOnce waits for controller to sync and writes the table of the
+// configured resource to w once, without the cursor and selection marks of
+// the TUI. The column titles are left out unless headers is set. The lines
+// are cut to the width set with WithWidth, if any.
+func PrintOnce(controller *controller.Controller, w io.Writer, headers bool, opts ...Option) error {
+	m, err := InitialModel(controller, opts...)
+	if err != nil {
+		return err
+	}
+	for !controller.HasSynced() {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Without a width nothing is truncated
+	m.width = m.outputWidth
+
+	m.loadSnapshots()
+	m.refreshChoices()
+
+	var table strings.Builder
+	writer := m.table.newWriter(&table)
+	m.writeTable(writer, headers, false)
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	for _, line := range strings.SplitAfter(table.String(), "\n") {
+		if m.width > 0 && line != "" {
+			line = ansi.Truncate(strings.TrimSuffix(line, "\n"), m.width, ellipsis) + "\n"
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
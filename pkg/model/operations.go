@@ -0,0 +1,183 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/AClarkie/k8s-tui/pkg/controller"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// operationDoneMsg reports that an imperative operation started with
+// runOperation has finished.
+type operationDoneMsg struct {
+	err error
+}
+
+// confirmation is a yes/no question which has to be answered before any other
+// key is handled.
+type confirmation struct {
+	prompt string
+	onYes  func(m *model) tea.Cmd
+}
+
+// runOperation marks an operation as in flight and returns the command which
+// performs it in the background.
+func (m *model) runOperation(op func() error) tea.Cmd {
+	m.pending++
+	return func() tea.Msg {
+		return operationDoneMsg{err: op()}
+	}
+}
+
+// quit exits the program, asking first if operations are still in flight.
+func (m *model) quit() tea.Cmd {
+	if m.pending == 0 {
+		return tea.Quit
+	}
+	m.confirm = &confirmation{
+		prompt: "Operations in progress, quit anyway?",
+		onYes: func(m *model) tea.Cmd {
+			return tea.Quit
+		},
+	}
+	return nil
+}
+
+// handleConfirmation answers the outstanding confirmation with key, only "y"
+// counts as yes.
+func (m *model) handleConfirmation(key string) tea.Cmd {
+	confirm := m.confirm
+	m.confirm = nil
+	if key == "y" {
+		return confirm.onYes(m)
+	}
+	return nil
+}
+
+// currentDeployment returns the namespace and name of the deployment under the
+// cursor.
+func (m *model) currentDeployment() (string, string, bool) {
+	if m.resource != deploymentsResource || m.cursor >= len(m.choices) {
+		return "", "", false
+	}
+	namespace, name := splitKey(m.choices[m.cursor])
+	return namespace, name, true
+}
+
+// deleteDeployment asks for confirmation before deleting the deployment under
+// the cursor.
+func (m *model) deleteDeployment() {
+	namespace, name, ok := m.currentDeployment()
+	if !ok {
+		return
+	}
+	m.confirmDelete(namespace, name)
+}
+
+// confirmDelete asks for confirmation before deleting the deployment
+// namespace/name.
+func (m *model) confirmDelete(namespace, name string) {
+	m.confirm = &confirmation{
+		prompt: "Delete deployment " + namespace + "/" + name + "?",
+		onYes: func(m *model) tea.Cmd {
+			return m.runOperation(func() error {
+				return m.controller.DeleteDeployment(namespace, name)
+			})
+		},
+	}
+}
+
+// restartDeployment restarts the pods of the deployment under the cursor,
+// following the rollout it starts.
+func (m *model) restartDeployment() tea.Cmd {
+	namespace, name, ok := m.currentDeployment()
+	if !ok {
+		return nil
+	}
+	return m.followRollout(namespace, name, func() error {
+		return m.controller.RestartDeployment(namespace, name)
+	})
+}
+
+// scaleToZero asks for confirmation before scaling the deployment under the
+// cursor to zero replicas.
+func (m *model) scaleToZero() {
+	namespace, name, ok := m.currentDeployment()
+	if !ok {
+		return
+	}
+	m.confirm = &confirmation{
+		prompt: "Scale deployment " + namespace + "/" + name + " to zero?",
+		onYes: func(m *model) tea.Cmd {
+			return m.runOperation(func() error {
+				return m.controller.ScaleToZero(namespace, name)
+			})
+		},
+	}
+}
+
+// restoreReplicas asks for confirmation before scaling the deployment under
+// the cursor back to the replicas it had before being scaled to zero. When
+// that count is unknown, e.g. it was scaled down by other means, it prompts
+// for the replicas instead.
+func (m *model) restoreReplicas() {
+	namespace, name, ok := m.currentDeployment()
+	if !ok {
+		return
+	}
+	deployment := m.deployments[namespace+"/"+name]
+	if deployment == nil {
+		return
+	}
+
+	restore := func(m *model, replicas int32) tea.Cmd {
+		return m.runOperation(func() error {
+			return m.controller.RestoreReplicas(namespace, name, replicas)
+		})
+	}
+	if previous, err := strconv.ParseInt(deployment.Annotations[controller.PreviousReplicasAnnotation], 10, 32); err == nil {
+		m.confirm = &confirmation{
+			prompt: fmt.Sprintf("Restore deployment %s/%s to %d replicas?", namespace, name, previous),
+			onYes: func(m *model) tea.Cmd {
+				return restore(m, int32(previous))
+			},
+		}
+		return
+	}
+	m.prompt = newPrompt("Previous replicas unknown, restore "+namespace+"/"+name+" to: ", func(m *model, value string) tea.Cmd {
+		if value == "" {
+			return nil
+		}
+		replicas, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || replicas < 0 {
+			m.showError(fmt.Errorf("invalid replicas %q, expected a non negative number", value))
+			return nil
+		}
+		return restore(m, int32(replicas))
+	})
+}
+
+// scaleDeployment changes the desired replicas of the deployment under the
+// cursor by delta.
+func (m *model) scaleDeployment(delta int32) tea.Cmd {
+	namespace, name, ok := m.currentDeployment()
+	if !ok {
+		return nil
+	}
+	deployment := m.deployments[namespace+"/"+name]
+	if deployment == nil {
+		return nil
+	}
+
+	replicas := delta
+	if deployment.Spec.Replicas != nil {
+		replicas += *deployment.Spec.Replicas
+	}
+	if replicas < 0 {
+		return nil
+	}
+	return m.runOperation(func() error {
+		return m.controller.ScaleDeployment(namespace, name, replicas)
+	})
+}
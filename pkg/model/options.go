@@ -0,0 +1,169 @@
+package model
+
+// Option configures the model created by InitialModel.
+type Option func(*options)
+
+type options struct {
+	columns  string
+	groupBy  string
+	table    tableFormat
+	sort     sortMode
+	resource resource
+	// resourceName overrides resource with the tab of that name, if set
+	resourceName string
+	context      string
+	eventDriven  bool
+	filter       string
+	asUser       string
+	asGroups     []string
+	focus        string
+	verboseAge   bool
+	width        int
+	timeFormat   string
+	// The comma separated annotations hidden from the detail view and the
+	// ones shown anyway
+	hideAnnotations string
+	showAnnotations string
+	pins            map[string]struct{}
+	insecureTLS     bool
+}
+
+func defaultOptions() *options {
+	return &options{
+		columns:         DefaultColumns,
+		table:           defaultTableFormat,
+		timeFormat:      DefaultTimeFormat,
+		hideAnnotations: DefaultHiddenAnnotations,
+		pins:            make(map[string]struct{}),
+	}
+}
+
+// WithColumns sets the comma separated columns of the deployment table, see
+// parseColumns for the accepted entries.
+func WithColumns(spec string) Option {
+	return func(o *options) {
+		o.columns = spec
+	}
+}
+
+// WithGroupBy groups the deployment rows by the value of their label key.
+func WithGroupBy(key string) Option {
+	return func(o *options) {
+		o.groupBy = key
+	}
+}
+
+// WithUnhealthyFirst starts the deployment table sorted with the unhealthy
+// deployments on top.
+func WithUnhealthyFirst(unhealthyFirst bool) Option {
+	return func(o *options) {
+		if unhealthyFirst {
+			o.sort = sortByHealth
+		}
+	}
+}
+
+// WithResource makes the tab of the named resource, such as "pods", active
+// at launch. InitialModel fails if no such resource is watched.
+func WithResource(name string) Option {
+	return func(o *options) {
+		o.resourceName = name
+	}
+}
+
+// WithContext names the kubeconfig context in use, which is passed on to the
+// kubectl commands the model generates.
+func WithContext(name string) Option {
+	return func(o *options) {
+		o.context = name
+	}
+}
+
+// WithImpersonation names the user and groups the controller impersonates, so
+// that the status bar and kubectl commands reflect it. An empty user means no
+// impersonation.
+func WithImpersonation(user string, groups []string) Option {
+	return func(o *options) {
+		o.asUser = user
+		o.asGroups = groups
+	}
+}
+
+// WithInsecureTLS warns in the status bar that the certificate of the API
+// server isn't verified.
+func WithInsecureTLS(insecure bool) Option {
+	return func(o *options) {
+		o.insecureTLS = insecure
+	}
+}
+
+// WithFocus shows a dashboard of the deployment called name rather than the
+// resource tabs, the controller being expected to only watch deployments with
+// that name.
+func WithFocus(name string) Option {
+	return func(o *options) {
+		o.focus = name
+		if name != "" {
+			o.resource = deploymentsResource
+			o.resourceName = ""
+		}
+	}
+}
+
+// WithVerboseAge shows the ages in their two largest units, e.g. 3d4h, rather
+// than only the largest one.
+func WithVerboseAge(verbose bool) Option {
+	return func(o *options) {
+		o.verboseAge = verbose
+	}
+}
+
+// WithWidth sets the width PrintOnce fits the table to, zero for no limit.
+func WithWidth(width int) Option {
+	return func(o *options) {
+		o.width = width
+	}
+}
+
+// WithTimeFormat sets how the status bar shows the last refresh time: 24h,
+// 12h, 24h-date, 12h-date or relative, e.g. 2s ago.
+func WithTimeFormat(format string) Option {
+	return func(o *options) {
+		o.timeFormat = format
+	}
+}
+
+// WithAnnotationFilter sets the comma separated annotations the detail view
+// hides until the "a" key is pressed, DefaultHiddenAnnotations by default,
+// and those it shows even though they match one of hide.
+func WithAnnotationFilter(hide, show string) Option {
+	return func(o *options) {
+		o.hideAnnotations = hide
+		o.showAnnotations = show
+	}
+}
+
+// WithEventDriven reloads the snapshots only when the controller reports a
+// change instead of polling them every second, so an idle model doesn't wake
+// up at all.
+func WithEventDriven(eventDriven bool) Option {
+	return func(o *options) {
+		o.eventDriven = eventDriven
+	}
+}
+
+// WithTablePadding sets the number of padChar characters separating the
+// table columns.
+func WithTablePadding(padding int, padChar byte) Option {
+	return func(o *options) {
+		o.table.padding = padding
+		o.table.padChar = padChar
+	}
+}
+
+// WithAlignRight right aligns the table cells instead of left aligning them.
+func WithAlignRight(alignRight bool) Option {
+	return func(o *options) {
+		o.table.alignRight = alignRight
+	}
+}
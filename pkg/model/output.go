@@ -0,0 +1,51 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// selectAndQuit quits, keeping the object under the cursor so that it can be
+// printed once the program has finished.
+func (m *model) selectAndQuit() tea.Cmd {
+	if m.cursor >= len(m.choices) {
+		return nil
+	}
+	obj, ok := m.object(m.choices[m.cursor])
+	if !ok {
+		return nil
+	}
+	m.output, _ = obj.(runtime.Object)
+	return m.quit()
+}
+
+// SelectedJSON returns the JSON of the object chosen to be printed in the model
+// returned by a finished program, if any.
+func SelectedJSON(m tea.Model) ([]byte, bool, error) {
+	final, ok := m.(model)
+	if !ok || final.output == nil {
+		return nil, false, nil
+	}
+
+	// Objects from the informers have no kind set, put it back like kubectl
+	obj := final.output.DeepCopyObject()
+	kinds, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to find the kind of the selected object, got err: %w", err)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(kinds[0])
+	if accessor, ok := obj.(meta_v1.Object); ok {
+		accessor.SetManagedFields(nil)
+	}
+
+	data, err := json.MarshalIndent(obj, "", "    ")
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to encode the selected object, got err: %w", err)
+	}
+	return data, true, nil
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxOwnerDepth bounds the owner chain in case of a reference cycle.
+const maxOwnerDepth = 10
+
+// ownerChain walks the controller references of obj through the snapshots,
+// returning the kind and name of obj followed by those of each owner. Owners
+// of kinds which aren't watched, such as DaemonSets and StatefulSets, end the
+// chain.
+func (m model) ownerChain(kind string, obj meta_v1.Object) []string {
+	chain := []string{kind + " " + obj.GetName()}
+	for i := 0; i < maxOwnerDepth; i++ {
+		ref := meta_v1.GetControllerOf(obj)
+		if ref == nil {
+			break
+		}
+		chain = append(chain, ref.Kind+" "+ref.Name)
+
+		owner, ok := m.owner(obj.GetNamespace(), ref)
+		if !ok {
+			break
+		}
+		obj = owner
+	}
+	return chain
+}
+
+// owner looks the object referenced by ref up in the snapshots.
+func (m model) owner(namespace string, ref *meta_v1.OwnerReference) (meta_v1.Object, bool) {
+	key := namespace + "/" + ref.Name
+	var obj meta_v1.Object
+	var ok bool
+	switch ref.Kind {
+	case "ReplicaSet":
+		obj, ok = m.replicaSets[key]
+	case "Deployment":
+		obj, ok = m.deployments[key]
+	case "Job":
+		obj, ok = m.jobs[key]
+	case "CronJob":
+		obj, ok = m.cronJobs[key]
+	}
+	// Owners are matched by UID as well in case one was recreated
+	if !ok || obj.GetUID() != ref.UID {
+		return nil, false
+	}
+	return obj, true
+}
+
+// podDetail renders the owner chain of pod.
+func (m model) podDetail(pod *corev1.Pod) string {
+	return fmt.Sprintf("Owners:    %s\n", strings.Join(m.ownerChain("Pod", pod), " → "))
+}
@@ -0,0 +1,263 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AClarkie/k8s-tui/pkg/controller"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// paletteCommand is a command of the command palette.
+type paletteCommand struct {
+	usage   string
+	mutates bool // whether the command changes the cluster
+	// complete returns the candidates for the first argument, if any
+	complete func(m *model) []string
+	run      func(m *model, args []string) (tea.Cmd, error)
+}
+
+// paletteCommands are the commands of the command palette by name. New
+// actions should be added here as well as to the key bindings.
+var paletteCommands = map[string]paletteCommand{
+	"scale": {
+		mutates:  true,
+		usage:    "scale <deployment> <replicas>",
+		complete: (*model).deploymentNames,
+		run: func(m *model, args []string) (tea.Cmd, error) {
+			if len(args) != 2 {
+				return nil, errors.New("expected a deployment and a number of replicas")
+			}
+			namespace, name, err := m.findDeployment(args[0])
+			if err != nil {
+				return nil, err
+			}
+			replicas, err := strconv.ParseInt(args[1], 10, 32)
+			if err != nil || replicas < 0 {
+				return nil, fmt.Errorf("invalid number of replicas %q", args[1])
+			}
+			return m.runOperation(func() error {
+				return m.controller.ScaleDeployment(namespace, name, int32(replicas))
+			}), nil
+		},
+	},
+	"delete": {
+		mutates:  true,
+		usage:    "delete <deployment>",
+		complete: (*model).deploymentNames,
+		run: func(m *model, args []string) (tea.Cmd, error) {
+			if len(args) != 1 {
+				return nil, errors.New("expected a deployment")
+			}
+			namespace, name, err := m.findDeployment(args[0])
+			if err != nil {
+				return nil, err
+			}
+			m.confirmDelete(namespace, name)
+			return nil, nil
+		},
+	},
+	"restart": {
+		mutates:  true,
+		usage:    "restart <deployment>",
+		complete: (*model).deploymentNames,
+		run: func(m *model, args []string) (tea.Cmd, error) {
+			if len(args) != 1 {
+				return nil, errors.New("expected a deployment")
+			}
+			namespace, name, err := m.findDeployment(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return m.followRollout(namespace, name, func() error {
+				return m.controller.RestartDeployment(namespace, name)
+			}), nil
+		},
+	},
+	"ns": {
+		usage:    "ns <namespace|all>",
+		complete: (*model).namespaceNames,
+		run: func(m *model, args []string) (tea.Cmd, error) {
+			if len(args) != 1 {
+				return nil, errors.New("expected a namespace, or all")
+			}
+			namespace := args[0]
+			if namespace == "all" {
+				namespace = ""
+			}
+			m.controller.SetNamespace(namespace)
+			return nil, nil
+		},
+	},
+	"filter": {
+		usage: "filter [text]",
+		run: func(m *model, args []string) (tea.Cmd, error) {
+			m.setFilter(strings.Join(args, " "))
+			return nil, nil
+		},
+	},
+}
+
+// openPalette prompts for a command of the command palette.
+func (m *model) openPalette() tea.Cmd {
+	m.prompt = newPrompt(":", func(m *model, value string) tea.Cmd {
+		if value == "" {
+			return nil
+		}
+		cmd, err := m.runPaletteCommand(value)
+		if err != nil {
+			m.showError(err)
+			return nil
+		}
+		return cmd
+	})
+	m.prompt.complete = completePaletteCommand
+	return textinput.Blink
+}
+
+// runPaletteCommand parses and runs a command line of the command palette.
+func (m *model) runPaletteCommand(line string) (tea.Cmd, error) {
+	fields := strings.Fields(line)
+	command, ok := paletteCommands[fields[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown command %q, expected one of %s", fields[0], strings.Join(paletteCommandNames(), ", "))
+	}
+	if command.mutates && m.controller.ReadOnly() {
+		return nil, controller.ErrReadOnly
+	}
+	cmd, err := command.run(m, fields[1:])
+	if err != nil {
+		return nil, fmt.Errorf("%s, usage: %s", err, command.usage)
+	}
+	return cmd, nil
+}
+
+// completePaletteCommand completes the last word of line to the longest prefix
+// shared by its candidates: command names for the first word, and the names
+// the command expects for the second one.
+func completePaletteCommand(m *model, line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasSuffix(line, " ") {
+		fields = append(fields, "")
+	}
+
+	var candidates []string
+	switch len(fields) {
+	case 1:
+		candidates = paletteCommandNames()
+	case 2:
+		if command, ok := paletteCommands[fields[0]]; ok && command.complete != nil {
+			candidates = command.complete(m)
+		}
+	}
+
+	last := fields[len(fields)-1]
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, last) {
+			matches = append(matches, c)
+		}
+	}
+	if len(matches) == 0 {
+		return line
+	}
+
+	completed := commonPrefix(matches)
+	if len(matches) == 1 {
+		completed += " "
+	}
+	return strings.Join(append(fields[:len(fields)-1], completed), " ")
+}
+
+// commonPrefix returns the longest prefix shared by every one of words, which
+// must not be empty.
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, w := range words[1:] {
+		for !strings.HasPrefix(w, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// paletteCommandNames returns the sorted names of the palette commands.
+func paletteCommandNames() []string {
+	names := make([]string, 0, len(paletteCommands))
+	for name := range paletteCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// deploymentNames returns the names of the deployments in the snapshot, with
+// their namespace when the name alone is ambiguous.
+func (m *model) deploymentNames() []string {
+	counts := make(map[string]int)
+	for key := range m.deployments {
+		_, name := splitKey(key)
+		counts[name]++
+	}
+	names := make([]string, 0, len(m.deployments))
+	for key := range m.deployments {
+		if _, name := splitKey(key); counts[name] == 1 {
+			names = append(names, name)
+		} else {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// namespaceNames returns all and the namespaces of the objects in the
+// snapshots, or the namespaces fetched for the namespace picker.
+func (m *model) namespaceNames() []string {
+	seen := make(map[string]struct{})
+	for _, ns := range m.namespaces {
+		seen[ns] = struct{}{}
+	}
+	for key := range m.deployments {
+		namespace, _ := splitKey(key)
+		seen[namespace] = struct{}{}
+	}
+	for key := range m.pods {
+		namespace, _ := splitKey(key)
+		seen[namespace] = struct{}{}
+	}
+	names := []string{"all"}
+	for ns := range seen {
+		names = append(names, ns)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// findDeployment resolves the namespace/name or name of a deployment in the
+// snapshot.
+func (m *model) findDeployment(ref string) (string, string, error) {
+	if _, ok := m.deployments[ref]; ok && strings.Contains(ref, "/") {
+		namespace, name := splitKey(ref)
+		return namespace, name, nil
+	}
+	var found []string
+	for key := range m.deployments {
+		if _, name := splitKey(key); name == ref {
+			found = append(found, key)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", "", fmt.Errorf("no deployment called %q", ref)
+	case 1:
+		namespace, name := splitKey(found[0])
+		return namespace, name, nil
+	}
+	sort.Strings(found)
+	return "", "", fmt.Errorf("deployment %q is ambiguous, it could be any of %s", ref, strings.Join(found, ", "))
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// highlightStyle marks the current item of a picker.
+var highlightStyle = lipgloss.NewStyle().Bold(true).Reverse(true)
+
+// picker is a popup list from which a single item is chosen.
+type picker struct {
+	title    string
+	items    []string
+	cursor   int
+	current  int // index of the item highlighted as currently in use, -1 for none
+	onSelect func(m *model, i int) tea.Cmd
+}
+
+// newPicker creates a picker with the cursor on the current item.
+func newPicker(title string, items []string, current int, onSelect func(m *model, i int) tea.Cmd) *picker {
+	return &picker{
+		title:    title,
+		items:    items,
+		cursor:   max(current, 0),
+		current:  current,
+		onSelect: onSelect,
+	}
+}
+
+// handlePickerKey moves the cursor of the open picker, choosing the item under
+// it on enter and dismissing the picker on esc or q.
+func (m *model) handlePickerKey(key tea.KeyMsg) tea.Cmd {
+	p := m.picker
+	switch key.String() {
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.items)-1 {
+			p.cursor++
+		}
+	case "enter", " ":
+		m.picker = nil
+		return p.onSelect(m, p.cursor)
+	case "esc", "q":
+		m.picker = nil
+	}
+	return nil
+}
+
+func (p *picker) View() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s\n\n", p.title)
+	for i, item := range p.items {
+		cursor := " "
+		if p.cursor == i {
+			cursor = ">"
+		}
+		if p.current == i {
+			item = highlightStyle.Render("* " + item)
+		} else {
+			item = "  " + item
+		}
+		fmt.Fprintf(&builder, "%s %s\n", cursor, item)
+	}
+	builder.WriteString("\nPress enter to choose, esc to cancel.")
+	return builder.String()
+}
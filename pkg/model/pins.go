@@ -0,0 +1,48 @@
+package model
+
+import (
+	"sort"
+)
+
+// pinMark follows the selection mark of pinned rows.
+const pinMark = "*"
+
+// togglePin pins the deployment under the cursor, or unpins it when it is
+// pinned already.
+func (m *model) togglePin() {
+	if m.resource != deploymentsResource || m.cursor >= len(m.choices) {
+		return
+	}
+	key := m.choices[m.cursor]
+	if _, ok := m.pins[key]; ok {
+		delete(m.pins, key)
+	} else {
+		m.pins[key] = struct{}{}
+	}
+	m.refreshChoices()
+}
+
+// withPins adds the keys of the pinned deployments which still exist to keys,
+// which the filters may have left them out of.
+func (m model) withPins(keys []string) []string {
+	listed := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		listed[key] = struct{}{}
+	}
+	for key := range m.pins {
+		if _, ok := listed[key]; !ok && m.deployments[key] != nil {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// pinFirst moves the pinned keys ahead of the others, leaving the order
+// within each set as it is.
+func pinFirst(keys []string, pins map[string]struct{}) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		_, pi := pins[keys[i]]
+		_, pj := pins[keys[j]]
+		return pi && !pj
+	})
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// placeholder is rendered for values which are not known yet, such as the
+// node of a pod which has not been scheduled.
+const placeholder = "<none>"
+
+type podMsg map[string]*corev1.Pod
+
+func (m model) checkPods() tea.Cmd {
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return podMsg(m.controller.Pods())
+	})
+}
+
+// podRow appends the status, node and IP columns of pod to its key.
+func podRow(key string, pod *corev1.Pod) string {
+	if pod == nil {
+		return key
+	}
+	return fmt.Sprintf("%s\t%s\t%s\t%s", key, pod.Status.Phase, orPlaceholder(pod.Spec.NodeName), orPlaceholder(pod.Status.PodIP))
+}
+
+// orPlaceholder returns s, or the placeholder when s is empty.
+func orPlaceholder(s string) string {
+	if s == "" {
+		return placeholder
+	}
+	return s
+}
+
+// Pod row colors, lipgloss leaves them out when NO_COLOR is set
+var (
+	podRunningStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	podPendingStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	podFailedStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	podTerminatingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("5"))
+	podCrashLoopStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+)
+
+// podStyle returns the style of the row of pod: green when running or
+// succeeded, yellow when pending and red when failed. Pods being deleted and
+// crash looping ones stand out with their own colors.
+func podStyle(pod *corev1.Pod) lipgloss.Style {
+	switch {
+	case pod.DeletionTimestamp != nil:
+		return podTerminatingStyle
+	case crashLooping(pod):
+		return podCrashLoopStyle
+	}
+	switch pod.Status.Phase {
+	case corev1.PodRunning, corev1.PodSucceeded:
+		return podRunningStyle
+	case corev1.PodPending:
+		return podPendingStyle
+	case corev1.PodFailed:
+		return podFailedStyle
+	}
+	return lipgloss.NewStyle()
+}
+
+// crashLooping reports whether a container of pod is backing off restarting
+// after crashing.
+func crashLooping(pod *corev1.Pod) bool {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+				return true
+			}
+		}
+	}
+	return false
+}
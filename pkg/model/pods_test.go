@@ -0,0 +1,74 @@
+package model
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newPod returns a pod in phase whose containers are waiting for reasons.
+func newPod(phase corev1.PodPhase, reasons ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "web-1"},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+	for _, reason := range reasons {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}},
+		})
+	}
+	return pod
+}
+
+func TestPodStyle(t *testing.T) {
+	terminating := newPod(corev1.PodRunning)
+	terminating.DeletionTimestamp = &meta_v1.Time{}
+	initCrashLooping := newPod(corev1.PodPending)
+	initCrashLooping.Status.InitContainerStatuses = newPod("", "CrashLoopBackOff").Status.ContainerStatuses
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want lipgloss.Style
+	}{
+		{"running", newPod(corev1.PodRunning), podRunningStyle},
+		{"succeeded", newPod(corev1.PodSucceeded), podRunningStyle},
+		{"pending", newPod(corev1.PodPending, "ContainerCreating"), podPendingStyle},
+		{"failed", newPod(corev1.PodFailed), podFailedStyle},
+		{"unknown", newPod(corev1.PodUnknown), lipgloss.NewStyle()},
+		// Being deleted and crash looping take precedence over the phase
+		{"terminating", terminating, podTerminatingStyle},
+		{"crash looping", newPod(corev1.PodRunning, "", "CrashLoopBackOff"), podCrashLoopStyle},
+		{"init container crash looping", initCrashLooping, podCrashLoopStyle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := podStyle(tt.pod)
+			if got.GetForeground() != tt.want.GetForeground() || got.GetBold() != tt.want.GetBold() {
+				t.Errorf("podStyle() = %v, want %v", got.GetForeground(), tt.want.GetForeground())
+			}
+		})
+	}
+}
+
+func TestPodStyleNoColor(t *testing.T) {
+	// Color even though the output isn't a terminal
+	t.Setenv("CLICOLOR_FORCE", "1")
+	t.Setenv("NO_COLOR", "")
+	colored := podStyle(newPod(corev1.PodFailed)).Renderer(lipgloss.NewRenderer(io.Discard)).Render("web-1")
+	if !strings.Contains(colored, "\x1b[") {
+		t.Fatalf("Render() = %q, want it colored", colored)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	for _, pod := range []*corev1.Pod{newPod(corev1.PodRunning), newPod(corev1.PodFailed), newPod(corev1.PodRunning, "CrashLoopBackOff")} {
+		got := podStyle(pod).Renderer(lipgloss.NewRenderer(io.Discard)).Render("web-1")
+		if got != "web-1" {
+			t.Errorf("Render() = %q with NO_COLOR set, want %q", got, "web-1")
+		}
+	}
+}
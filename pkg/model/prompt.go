@@ -0,0 +1,60 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prompt is a single line text input shown in place of the footer.
+type prompt struct {
+	input    textinput.Model
+	onSubmit func(m *model, value string) tea.Cmd
+	// complete returns the input completed on tab, if set
+	complete func(m *model, value string) string
+}
+
+// newPrompt creates a focused prompt labelled with label, onSubmit is called
+// with the trimmed input once enter is pressed.
+func newPrompt(label string, onSubmit func(m *model, value string) tea.Cmd) *prompt {
+	input := textinput.New()
+	input.Prompt = label
+	input.Focus()
+	return &prompt{
+		input:    input,
+		onSubmit: onSubmit,
+	}
+}
+
+// handlePromptKey passes key to the open prompt, submitting it on enter and
+// dismissing it on esc.
+func (m *model) handlePromptKey(key tea.KeyMsg) tea.Cmd {
+	switch key.Type {
+	case tea.KeyEnter:
+		p := m.prompt
+		m.prompt = nil
+		return p.onSubmit(m, strings.TrimSpace(p.input.Value()))
+	case tea.KeyEsc:
+		m.prompt = nil
+		return nil
+	case tea.KeyTab:
+		if m.prompt.complete != nil {
+			m.prompt.input.SetValue(m.prompt.complete(m, m.prompt.input.Value()))
+			m.prompt.input.CursorEnd()
+			return nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.prompt.input, cmd = m.prompt.input.Update(key)
+	return cmd
+}
+
+// updatePrompt passes msg, a message other than a key press, to the open
+// prompt so that its cursor keeps blinking.
+func (m *model) updatePrompt(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.prompt.input, cmd = m.prompt.input.Update(msg)
+	return cmd
+}
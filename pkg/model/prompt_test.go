@@ -0,0 +1,30 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPromptCursorBlinks(t *testing.T) {
+	m := newTestModel(t)
+	m.startFilter()
+
+	// The first blink schedules the next one
+	_, cmd := m.Update(textinput.Blink())
+	if cmd == nil {
+		t.Fatal("Update(blink) returned no command, the prompt cursor doesn't blink")
+	}
+}
+
+func TestPromptIgnoresBlinksOnceClosed(t *testing.T) {
+	m := newTestModel(t)
+	m.startFilter()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	_, cmd := updated.Update(textinput.Blink())
+	if cmd != nil {
+		t.Error("Update(blink) returned a command with the prompt closed")
+	}
+}
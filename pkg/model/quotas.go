@@ -0,0 +1,76 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type quotaMsg map[string]*corev1.ResourceQuota
+
+func (m model) checkQuotas() tea.Cmd {
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return quotaMsg(m.controller.ResourceQuotas())
+	})
+}
+
+// quotaNames shortens the resource names of quotas for the compact quota
+// lines.
+var quotaNames = map[corev1.ResourceName]string{
+	corev1.ResourceMemory:         "mem",
+	corev1.ResourceRequestsMemory: "requests.mem",
+	corev1.ResourceLimitsMemory:   "limits.mem",
+}
+
+// namespaceQuotas returns the quotas of namespace, sorted by name.
+func namespaceQuotas(namespace string, quotas map[string]*corev1.ResourceQuota) []*corev1.ResourceQuota {
+	var matching []*corev1.ResourceQuota
+	for _, quota := range quotas {
+		if quota.Namespace == namespace {
+			matching = append(matching, quota)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Name < matching[j].Name
+	})
+	return matching
+}
+
+// quotaLine renders the usage of quota against its hard limits, e.g. "cpu:
+// 4/8, mem: 6Gi/16Gi", the resources sorted by name.
+func quotaLine(quota *corev1.ResourceQuota) string {
+	names := make([]string, 0, len(quota.Status.Hard))
+	for name := range quota.Status.Hard {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	entries := make([]string, len(names))
+	for i, name := range names {
+		hard := quota.Status.Hard[corev1.ResourceName(name)]
+		used := quota.Status.Used[corev1.ResourceName(name)]
+		label, ok := quotaNames[corev1.ResourceName(name)]
+		if !ok {
+			label = name
+		}
+		entries[i] = fmt.Sprintf("%s: %s/%s", label, used.String(), hard.String())
+	}
+	return strings.Join(entries, ", ")
+}
+
+// quotaLines renders a line per quota of namespace to go under its group
+// header, none when it has no quota.
+func quotaLines(namespace string, quotas map[string]*corev1.ResourceQuota) []string {
+	var lines []string
+	for _, quota := range namespaceQuotas(namespace, quotas) {
+		if line := quotaLine(quota); line != "" {
+			lines = append(lines, "  quota "+quota.Name+": "+line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// revisionAnnotation holds the rollout revision of a deployment and of each of
+// its replica sets.
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+type replicaSetMsg map[string]*appsv1.ReplicaSet
+
+func (m model) checkReplicaSets() tea.Cmd {
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return replicaSetMsg(m.controller.ReplicaSets())
+	})
+}
+
+// activeReplicaSet returns the replica set owned by d which has the same
+// revision as d, the one rolled out by its current pod template.
+func activeReplicaSet(d *appsv1.Deployment, replicaSets map[string]*appsv1.ReplicaSet) (*appsv1.ReplicaSet, bool) {
+	revision := d.Annotations[revisionAnnotation]
+	for _, rs := range replicaSets {
+		if rs.Namespace != d.Namespace || rs.Annotations[revisionAnnotation] != revision {
+			continue
+		}
+		for _, owner := range rs.OwnerReferences {
+			if owner.UID == d.UID {
+				return rs, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// templateHashDetail renders the revision of d and the pod-template-hash of
+// its active replica set.
+func templateHashDetail(d *appsv1.Deployment, replicaSets map[string]*appsv1.ReplicaSet) string {
+	revision, hash := placeholder, placeholder
+	if r := d.Annotations[revisionAnnotation]; r != "" {
+		revision = r
+	}
+	if rs, ok := activeReplicaSet(d, replicaSets); ok {
+		hash = fmt.Sprintf("%s (%s)", orPlaceholder(rs.Labels[appsv1.DefaultDeploymentUniqueLabelKey]), rs.Name)
+	}
+	return fmt.Sprintf("Revision:  %s\nTemplate:  %s\n", revision, hash)
+}
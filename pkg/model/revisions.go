@@ -0,0 +1,94 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/yaml"
+)
+
+// ownedReplicaSets returns the replica sets owned by d, latest revision first.
+func ownedReplicaSets(d *appsv1.Deployment, replicaSets map[string]*appsv1.ReplicaSet) []*appsv1.ReplicaSet {
+	var owned []*appsv1.ReplicaSet
+	for _, rs := range replicaSets {
+		if rs.Namespace != d.Namespace {
+			continue
+		}
+		for _, owner := range rs.OwnerReferences {
+			if owner.UID == d.UID {
+				owned = append(owned, rs)
+				break
+			}
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return replicaSetRevision(owned[i]) > replicaSetRevision(owned[j])
+	})
+	return owned
+}
+
+// replicaSetRevision returns the rollout revision of rs, zero when unknown.
+func replicaSetRevision(rs *appsv1.ReplicaSet) int64 {
+	revision, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+	return revision
+}
+
+// revisionSummary renders the revision, age and images of rs.
+func revisionSummary(rs *appsv1.ReplicaSet) string {
+	images := make([]string, len(rs.Spec.Template.Spec.Containers))
+	for i, c := range rs.Spec.Template.Spec.Containers {
+		images[i] = c.Image
+	}
+	return fmt.Sprintf("%d  %s  %s", replicaSetRevision(rs), duration.HumanDuration(time.Since(rs.CreationTimestamp.Time)), strings.Join(images, ", "))
+}
+
+// showRevisions lists the revisions of the deployment under the cursor, the
+// chosen one's pod template being previewed.
+func (m *model) showRevisions() tea.Cmd {
+	namespace, name, ok := m.currentDeployment()
+	if !ok {
+		return nil
+	}
+	deployment := m.deployments[namespace+"/"+name]
+	if deployment == nil {
+		return nil
+	}
+
+	owned := ownedReplicaSets(deployment, m.replicaSets)
+	if len(owned) == 0 {
+		return m.flash("No revisions of " + namespace + "/" + name + " found.")
+	}
+	items := make([]string, len(owned))
+	current := -1
+	for i, rs := range owned {
+		items[i] = revisionSummary(rs)
+		if rs.Annotations[revisionAnnotation] == deployment.Annotations[revisionAnnotation] {
+			current = i
+		}
+	}
+	m.picker = newPicker("Revisions of "+namespace+"/"+name+" (revision, age, images)", items, current, func(m *model, i int) tea.Cmd {
+		m.previewRevision(owned[i])
+		return nil
+	})
+	return nil
+}
+
+// previewRevision shows the pod template of the revision rolled out by rs.
+func (m *model) previewRevision(rs *appsv1.ReplicaSet) {
+	template := rs.Spec.Template.DeepCopy()
+	// The hash is added by the deployment controller, not part of the revision
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	data, err := yaml.Marshal(template)
+	if err != nil {
+		m.showError(fmt.Errorf("failed to encode pod template of %s, got err: %w", rs.Name, err))
+		return
+	}
+	m.openErrorPane(fmt.Sprintf("Revision %d (%s)", replicaSetRevision(rs), rs.Name), string(data))
+}
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// rolloutBarWidth caps the width of the rollout progress bar.
+const rolloutBarWidth = 40
+
+// rolloutDetail renders the number of updated replicas of d and a progress bar
+// of the rollout. It is empty when d has no replicas.
+func rolloutDetail(d *appsv1.Deployment, width int) string {
+	total := d.Status.Replicas
+	if total == 0 {
+		return ""
+	}
+	updated := d.Status.UpdatedReplicas
+
+	bar := progress.New(progress.WithDefaultGradient(), progress.WithWidth(min(width, rolloutBarWidth)))
+	return fmt.Sprintf("Rollout:   %d/%d updated\n  %s\n", updated, total, bar.ViewAs(float64(updated)/float64(total)))
+}
+
+// rolloutFollowTimeout is how long a followed rollout may take before it is
+// reported as timed out, should its progress deadline not catch it first.
+const rolloutFollowTimeout = 10 * time.Minute
+
+// rolloutState is how far a rollout has got.
+type rolloutState int
+
+const (
+	rolloutProgressing rolloutState = iota
+	rolloutComplete
+	rolloutFailed
+)
+
+// rolloutStatus describes the progress of the latest rollout of d like
+// kubectl rollout status does.
+func rolloutStatus(d *appsv1.Deployment) (string, rolloutState) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return "waiting for the deployment spec update to be observed", rolloutProgressing
+	}
+
+	var progressing *appsv1.DeploymentCondition
+	for i, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing {
+			progressing = &d.Status.Conditions[i]
+		}
+	}
+	if progressing != nil && progressing.Reason == "ProgressDeadlineExceeded" {
+		return fmt.Sprintf("deployment %s exceeded its progress deadline", d.Name), rolloutFailed
+	}
+
+	status := d.Status
+	desired := desiredReplicas(d)
+	switch {
+	case status.UpdatedReplicas < desired:
+		return fmt.Sprintf("%d of %d updated replicas, %d available, %d unavailable", status.UpdatedReplicas, desired, status.AvailableReplicas, status.UnavailableReplicas), rolloutProgressing
+	case status.Replicas > status.UpdatedReplicas:
+		return fmt.Sprintf("%d old replicas are pending termination", status.Replicas-status.UpdatedReplicas), rolloutProgressing
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return fmt.Sprintf("%d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas), rolloutProgressing
+	case progressing != nil && progressing.Reason != "NewReplicaSetAvailable":
+		return "waiting for the new replica set to be reported available", rolloutProgressing
+	}
+	return fmt.Sprintf("deployment %s successfully rolled out", d.Name), rolloutComplete
+}
+
+// rolloutStartedMsg reports the outcome of an operation starting a rollout of
+// the deployment stored under key.
+type rolloutStartedMsg struct {
+	key        string
+	generation int64 // generation of the deployment before the operation
+	err        error
+}
+
+// followRollout runs op, then follows the rollout it starts of the deployment
+// namespace/name in its detail view.
+func (m *model) followRollout(namespace, name string, op func() error) tea.Cmd {
+	key := namespace + "/" + name
+	var generation int64
+	if d := m.deployments[key]; d != nil {
+		generation = d.Generation
+	}
+	m.pending++
+	return func() tea.Msg {
+		return rolloutStartedMsg{key: key, generation: generation, err: op()}
+	}
+}
+
+// startFollowing opens the detail view of the deployment of msg and follows
+// its rollout.
+func (m *model) startFollowing(msg rolloutStartedMsg) {
+	m.pending--
+	if msg.err != nil {
+		m.showError(msg.err)
+		return
+	}
+	m.detail = msg.key
+	m.detailTop = 0
+	m.follow = followedRollout{key: msg.key, generation: msg.generation, since: time.Now(), active: true}
+}
+
+// followedRollout is the rollout followed in the detail view.
+type followedRollout struct {
+	key        string
+	generation int64     // the deployment generation before the rollout
+	since      time.Time // when following started
+	active     bool      // false once the rollout has finished
+	result     string    // how the rollout finished
+}
+
+// checkFollow ends following the rollout once it has completed, failed or
+// timed out, flashing the result.
+func (m *model) checkFollow() tea.Cmd {
+	f := &m.follow
+	if !f.active {
+		return nil
+	}
+
+	d := m.deployments[f.key]
+	var state rolloutState
+	switch {
+	case d == nil:
+		f.result, state = "deployment "+f.key+" no longer exists", rolloutFailed
+	case d.Generation <= f.generation:
+		// The cache hasn't seen the change starting the rollout yet
+		f.result, state = "waiting for the deployment spec update to be observed", rolloutProgressing
+	default:
+		f.result, state = rolloutStatus(d)
+	}
+
+	if state == rolloutProgressing && time.Since(f.since) > rolloutFollowTimeout {
+		f.result, state = fmt.Sprintf("timed out after %s waiting for the rollout", rolloutFollowTimeout), rolloutFailed
+	}
+	if state == rolloutProgressing {
+		return nil
+	}
+	f.active = false
+	return m.flash("Rollout of " + f.key + ": " + f.result)
+}
+
+// followDetail renders the progress of the rollout followed in the detail view
+// of the deployment stored under key, if any.
+func (m model) followDetail(key string) string {
+	switch {
+	case m.follow.key != key || m.follow.result == "":
+		return ""
+	case m.follow.active:
+		return fmt.Sprintf("Following: %s (%s)\n", m.follow.result, duration.HumanDuration(time.Since(m.follow.since)))
+	default:
+		return "Result:    " + m.follow.result + "\n"
+	}
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	appsv1 "k8s.io/api/apps/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// warningBadge starts the lines of the detail view flagging misconfigurations.
+var warningBadge = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("0")).Background(lipgloss.Color("11")).Render(" WARNING ")
+
+// selectorMismatch reports whether the selector of d doesn't match the labels
+// of its own pod template, so that it would never see the pods it creates.
+func selectorMismatch(d *appsv1.Deployment) bool {
+	selector, err := meta_v1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return true
+	}
+	return !selector.Matches(labels.Set(d.Spec.Template.Labels))
+}
+
+// selectorOverlaps returns the sorted keys of the other deployments in the
+// namespace of d whose pods the selector of d matches, or which match the
+// pods of d, so that both would claim the same pods.
+func selectorOverlaps(d *appsv1.Deployment, deployments map[string]*appsv1.Deployment) []string {
+	selector, err := meta_v1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return nil
+	}
+
+	var overlaps []string
+	for key, other := range deployments {
+		if other.Namespace != d.Namespace || other.Name == d.Name {
+			continue
+		}
+		otherSelector, err := meta_v1.LabelSelectorAsSelector(other.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(other.Spec.Template.Labels)) || otherSelector.Matches(labels.Set(d.Spec.Template.Labels)) {
+			overlaps = append(overlaps, key)
+		}
+	}
+	sort.Strings(overlaps)
+	return overlaps
+}
+
+// selectorDetail renders a warning for each selector misconfiguration of d,
+// or nothing when there are none.
+func selectorDetail(d *appsv1.Deployment, deployments map[string]*appsv1.Deployment) string {
+	var builder strings.Builder
+	if selectorMismatch(d) {
+		fmt.Fprintf(&builder, "%s the selector %s doesn't match the pod template labels\n", warningBadge, meta_v1.FormatLabelSelector(d.Spec.Selector))
+	}
+	if overlaps := selectorOverlaps(d, deployments); len(overlaps) > 0 {
+		fmt.Fprintf(&builder, "%s the selector overlaps with %s\n", warningBadge, strings.Join(overlaps, ", "))
+	}
+	return builder.String()
+}
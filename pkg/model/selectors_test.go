@@ -0,0 +1,87 @@
+package model
+
+import (
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// withSelector returns d selecting the pods labelled with selector and
+// creating pods labelled with template.
+func withSelector(d *appsv1.Deployment, selector *meta_v1.LabelSelector, template map[string]string) *appsv1.Deployment {
+	d.Spec.Selector = selector
+	d.Spec.Template.Labels = template
+	return d
+}
+
+func TestSelectorMismatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector *meta_v1.LabelSelector
+		template map[string]string
+		want     bool
+	}{
+		{"matching", &meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}, map[string]string{"app": "web", "tier": "frontend"}, false},
+		{"mismatched value", &meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}, map[string]string{"app": "api"}, true},
+		{"missing label", &meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "web", "tier": "frontend"}}, map[string]string{"app": "web"}, true},
+		{"matching expression", &meta_v1.LabelSelector{MatchExpressions: []meta_v1.LabelSelectorRequirement{
+			{Key: "app", Operator: meta_v1.LabelSelectorOpIn, Values: []string{"web", "api"}},
+		}}, map[string]string{"app": "api"}, false},
+		{"mismatched expression", &meta_v1.LabelSelector{MatchExpressions: []meta_v1.LabelSelectorRequirement{
+			{Key: "app", Operator: meta_v1.LabelSelectorOpNotIn, Values: []string{"web"}},
+		}}, map[string]string{"app": "web"}, true},
+		{"invalid", &meta_v1.LabelSelector{MatchExpressions: []meta_v1.LabelSelectorRequirement{{Key: "app", Operator: "Like"}}}, map[string]string{"app": "web"}, true},
+		{"no selector", nil, map[string]string{"app": "web"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := withSelector(newDeployment("default/web", 1, 1), tt.selector, tt.template)
+			if got := selectorMismatch(d); got != tt.want {
+				t.Errorf("selectorMismatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectorOverlaps(t *testing.T) {
+	invalid := &meta_v1.LabelSelector{MatchExpressions: []meta_v1.LabelSelectorRequirement{{Key: "app", Operator: "Like"}}}
+	app := func(value string) map[string]string { return map[string]string{"app": value} }
+	selecting := func(labels map[string]string) *meta_v1.LabelSelector {
+		return &meta_v1.LabelSelector{MatchLabels: labels}
+	}
+	deployments := map[string]*appsv1.Deployment{
+		"default/web":  withSelector(newDeployment("default/web", 1, 1), selecting(app("web")), app("web")),
+		"default/api":  withSelector(newDeployment("default/api", 1, 1), selecting(app("api")), app("api")),
+		"default/all":  withSelector(newDeployment("default/all", 1, 1), &meta_v1.LabelSelector{}, app("all")),
+		"default/copy": withSelector(newDeployment("default/copy", 1, 1), selecting(app("web")), map[string]string{"app": "web", "copy": "true"}),
+		"other/web":    withSelector(newDeployment("other/web", 1, 1), selecting(app("web")), app("web")),
+		"other/bad":    withSelector(newDeployment("other/bad", 1, 1), invalid, app("bad")),
+	}
+
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		// The empty selector of default/all matches the pods of every
+		// deployment in its namespace
+		{"default/web", []string{"default/all", "default/copy"}},
+		{"default/api", []string{"default/all"}},
+		{"default/all", []string{"default/api", "default/copy", "default/web"}},
+		// The pods of default/web have no "copy" label, but the selector of
+		// default/copy still matches them
+		{"default/copy", []string{"default/all", "default/web"}},
+		// Deployments in other namespaces never overlap, nor do ones
+		// without a valid selector
+		{"other/web", nil},
+		{"other/bad", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := selectorOverlaps(deployments[tt.key], deployments); !slices.Equal(got, tt.want) {
+				t.Errorf("selectorOverlaps(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
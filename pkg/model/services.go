@@ -0,0 +1,136 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type serviceMsg map[string]*corev1.Service
+
+func (m model) checkServices() tea.Cmd {
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return serviceMsg(m.controller.Services())
+	})
+}
+
+type endpointSliceMsg map[string]*discoveryv1.EndpointSlice
+
+func (m model) checkEndpointSlices() tea.Cmd {
+	d := time.Second * 1
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return endpointSliceMsg(m.controller.EndpointSlices())
+	})
+}
+
+// serviceRow appends the type, cluster IP, ports and ready endpoints columns
+// of service to its key.
+func serviceRow(key string, service *corev1.Service, slices map[string]*discoveryv1.EndpointSlice) string {
+	if service == nil {
+		return key
+	}
+	ready, _ := serviceEndpoints(service, slices)
+	return fmt.Sprintf("%s\t%s\t%s\t%s\t%d", key, service.Spec.Type, orPlaceholder(service.Spec.ClusterIP), orPlaceholder(servicePorts(service)), ready)
+}
+
+// servicePorts renders the ports of service as port/protocol pairs.
+func servicePorts(service *corev1.Service) string {
+	ports := make([]string, len(service.Spec.Ports))
+	for i, port := range service.Spec.Ports {
+		ports[i] = fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+	}
+	return strings.Join(ports, ",")
+}
+
+// serviceSlices returns the endpoint slices of service, sorted by name.
+func serviceSlices(service *corev1.Service, slices map[string]*discoveryv1.EndpointSlice) []*discoveryv1.EndpointSlice {
+	var matching []*discoveryv1.EndpointSlice
+	for _, slice := range slices {
+		if slice.Namespace == service.Namespace && slice.Labels[discoveryv1.LabelServiceName] == service.Name {
+			matching = append(matching, slice)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Name < matching[j].Name
+	})
+	return matching
+}
+
+// serviceEndpoints counts the ready and not ready endpoint addresses backing
+// service.
+func serviceEndpoints(service *corev1.Service, slices map[string]*discoveryv1.EndpointSlice) (int, int) {
+	var ready, notReady int
+	for _, slice := range serviceSlices(service, slices) {
+		for _, endpoint := range slice.Endpoints {
+			// A nil ready condition means unknown, which consumers treat as ready
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready += len(endpoint.Addresses)
+			} else {
+				notReady += len(endpoint.Addresses)
+			}
+		}
+	}
+	return ready, notReady
+}
+
+// serviceDetail renders the selector of service and the counts of its ready
+// and not ready endpoint addresses.
+func serviceDetail(service *corev1.Service, slices map[string]*discoveryv1.EndpointSlice) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Type:      %s\n", service.Spec.Type)
+	fmt.Fprintf(&builder, "Ports:     %s\n", orPlaceholder(servicePorts(service)))
+	builder.WriteString("Selector:\n")
+	builder.WriteString(mapDetail(service.Spec.Selector))
+
+	ready, notReady := serviceEndpoints(service, slices)
+	fmt.Fprintf(&builder, "Endpoints: %d ready, %d not ready\n", ready, notReady)
+	if ready == 0 {
+		builder.WriteString("  Nothing is serving this service.\n")
+	}
+	for _, slice := range serviceSlices(service, slices) {
+		fmt.Fprintf(&builder, "  %s: %d endpoint(s)\n", slice.Name, len(slice.Endpoints))
+	}
+	return builder.String()
+}
+
+// deploymentServices returns the services in the namespace of d whose
+// selector matches the pod template labels of d, sorted by name. Services
+// without a selector are left out, their endpoints are managed by hand.
+func deploymentServices(d *appsv1.Deployment, services map[string]*corev1.Service) []*corev1.Service {
+	var matching []*corev1.Service
+	podLabels := labels.Set(d.Spec.Template.Labels)
+	for _, service := range services {
+		if service.Namespace != d.Namespace || len(service.Spec.Selector) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(service.Spec.Selector).Matches(podLabels) {
+			matching = append(matching, service)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Name < matching[j].Name
+	})
+	return matching
+}
+
+// exposureDetail renders the type, cluster IP and ports of the services
+// exposing a deployment.
+func exposureDetail(services []*corev1.Service) string {
+	var builder strings.Builder
+	builder.WriteString("Services:\n")
+	if len(services) == 0 {
+		builder.WriteString("  " + placeholder + "\n")
+	}
+	for _, service := range services {
+		fmt.Fprintf(&builder, "  %s: %s %s %s\n", service.Name, service.Spec.Type, orPlaceholder(service.Spec.ClusterIP), orPlaceholder(servicePorts(service)))
+	}
+	return builder.String()
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// sortMode is the order in which deployments are listed.
+type sortMode int
+
+const (
+	sortByName sortMode = iota
+	sortByReady
+	sortByHealth // unhealthy deployments first
+)
+
+// sortModes lists the sort modes in the order they are cycled through.
+var sortModes = []sortMode{sortByName, sortByReady, sortByHealth}
+
+func (s sortMode) String() string {
+	switch s {
+	case sortByReady:
+		return "ready"
+	case sortByHealth:
+		return "health"
+	default:
+		return "name"
+	}
+}
+
+// next returns the sort mode following s.
+func (s sortMode) next() sortMode {
+	return sortModes[(int(s)+1)%len(sortModes)]
+}
+
+// sortColumns lists the column spec entries each sort mode orders by, the
+// first of them which is shown carries the sort direction arrow.
+var sortColumns = map[sortMode][]string{
+	sortByName:  {"namespace", "name"},
+	sortByReady: {"ready"},
+}
+
+// sortArrow returns the arrow showing the sort direction.
+func sortArrow(desc bool) string {
+	if desc {
+		return "▼"
+	}
+	return "▲"
+}
+
+// sortColumn returns the index of the column carrying the sort direction
+// arrow for mode, or -1 when mode orders by none of columns.
+func sortColumn(columns []column, mode sortMode) int {
+	for _, entry := range sortColumns[mode] {
+		for i, c := range columns {
+			if c.entry == entry {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// sortDeployments orders the namespace/name keys of deployments by mode,
+// reversed when desc is set. Keys which compare equal under mode fall back to
+// namespace/name, so rows never swap places between refreshes.
+func sortDeployments(keys []string, deployments map[string]*appsv1.Deployment, mode sortMode, desc bool) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		if desc {
+			i, j = j, i
+		}
+		switch mode {
+		case sortByReady:
+			ri, rj := readyRatio(deployments[keys[i]]), readyRatio(deployments[keys[j]])
+			if ri != rj {
+				return ri < rj
+			}
+		case sortByHealth:
+			hi, hj := deploymentHealthy(deployments[keys[i]]), deploymentHealthy(deployments[keys[j]])
+			if hi != hj {
+				return !hi
+			}
+		}
+		return keys[i] < keys[j]
+	})
+}
+
+// readyRatio returns the fraction of desired replicas of d which are ready, a
+// deployment without desired replicas counts as fully ready.
+func readyRatio(d *appsv1.Deployment) float64 {
+	desired := desiredReplicas(d)
+	if desired == 0 {
+		return 1
+	}
+	return float64(d.Status.ReadyReplicas) / float64(desired)
+}
+
+// desiredReplicas returns the number of replicas requested by d, which
+// defaults to one when unset.
+func desiredReplicas(d *appsv1.Deployment) int32 {
+	if d == nil {
+		return 0
+	}
+	if d.Spec.Replicas == nil {
+		return 1
+	}
+	return *d.Spec.Replicas
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newDeployment returns a deployment stored under key wanting replicas of
+// which ready are ready.
+func newDeployment(key string, replicas, ready int32) *appsv1.Deployment {
+	namespace, name := splitKey(key)
+	return &appsv1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+	}
+}
+
+func TestSortDeploymentsIsStable(t *testing.T) {
+	// Every deployment shares its ready ratio and health with another one
+	deployments := map[string]*appsv1.Deployment{
+		"b/web":    newDeployment("b/web", 2, 1),
+		"a/web":    newDeployment("a/web", 4, 2),
+		"a/api":    newDeployment("a/api", 1, 1),
+		"c/api":    newDeployment("c/api", 3, 3),
+		"a/worker": newDeployment("a/worker", 2, 1),
+	}
+
+	for _, mode := range sortModes {
+		for _, desc := range []bool{false, true} {
+			// Start from a different order every time
+			orders := [][]string{
+				{"b/web", "a/web", "a/api", "c/api", "a/worker"},
+				{"a/worker", "c/api", "a/api", "a/web", "b/web"},
+				{"a/api", "a/web", "a/worker", "b/web", "c/api"},
+			}
+			var want []string
+			for i, keys := range orders {
+				for range 3 {
+					sortDeployments(keys, deployments, mode, desc)
+				}
+				if i == 0 {
+					want = keys
+					continue
+				}
+				if !slices.Equal(keys, want) {
+					t.Errorf("sortDeployments(%v, desc %v) = %v, want %v", mode, desc, keys, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSortDeploymentsBreaksTiesByKey(t *testing.T) {
+	deployments := map[string]*appsv1.Deployment{
+		"b/web":    newDeployment("b/web", 2, 1),
+		"a/web":    newDeployment("a/web", 4, 2),
+		"a/api":    newDeployment("a/api", 1, 1),
+		"a/worker": newDeployment("a/worker", 2, 1),
+	}
+
+	tests := []struct {
+		mode sortMode
+		desc bool
+		want []string
+	}{
+		{sortByName, false, []string{"a/api", "a/web", "a/worker", "b/web"}},
+		{sortByName, true, []string{"b/web", "a/worker", "a/web", "a/api"}},
+		{sortByReady, false, []string{"a/web", "a/worker", "b/web", "a/api"}},
+		{sortByReady, true, []string{"a/api", "b/web", "a/worker", "a/web"}},
+		{sortByHealth, false, []string{"a/web", "a/worker", "b/web", "a/api"}},
+	}
+	for _, tt := range tests {
+		keys := []string{"b/web", "a/worker", "a/api", "a/web"}
+		sortDeployments(keys, deployments, tt.mode, tt.desc)
+		if !slices.Equal(keys, tt.want) {
+			t.Errorf("sortDeployments(%v, desc %v) = %v, want %v", tt.mode, tt.desc, keys, tt.want)
+		}
+	}
+}
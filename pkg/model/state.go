@@ -0,0 +1,87 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// State is the part of the UI which is remembered between runs.
+type State struct {
+	Resource string `json:"resource,omitempty"`
+	Sort     string `json:"sort,omitempty"`
+	Filter   string `json:"filter,omitempty"`
+	// Pins are the namespace/name keys of the pinned deployments
+	Pins []string `json:"pins,omitempty"`
+}
+
+// LoadState reads the state saved at path. A missing file yields the zero
+// State.
+func LoadState(path string) (State, error) {
+	var state State
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read state, got err: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("failed to decode state, got err: %w", err)
+	}
+	return state, nil
+}
+
+// SaveState writes state to path, creating its directory if needed.
+func SaveState(path string, state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode state, got err: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory, got err: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write state, got err: %w", err)
+	}
+	return nil
+}
+
+// FinalState returns the State of the model returned by a finished program.
+func FinalState(m tea.Model) (State, bool) {
+	final, ok := m.(model)
+	if !ok {
+		return State{}, false
+	}
+	return State{
+		Resource: final.resource.String(),
+		Sort:     final.sortMode.String(),
+		Filter:   final.filters[final.resource],
+		Pins:     convertToSliceAndSort(final.pins),
+	}, true
+}
+
+// WithState restores the tab, sort mode, filter and pinned deployments of
+// state, the filter applying to the restored tab. Unknown values
+// are ignored.
+func WithState(state State) Option {
+	return func(o *options) {
+		for _, r := range resources {
+			if r.String() == state.Resource {
+				o.resource = r
+			}
+		}
+		for _, s := range sortModes {
+			if s.String() == state.Sort {
+				o.sort = s
+			}
+		}
+		o.filter = state.Filter
+		for _, key := range state.Pins {
+			o.pins[key] = struct{}{}
+		}
+	}
+}
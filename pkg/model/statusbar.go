@@ -0,0 +1,73 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// insecureStyle makes the warning about the unverified API server stand out.
+var insecureStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15")).Background(lipgloss.Color("9"))
+
+// statusBar renders the one line summary of the model state shown below the
+// table.
+func (m model) statusBar() string {
+	var parts []string
+	if m.insecureTLS {
+		parts = append(parts, insecureStyle.Render("INSECURE: TLS verification disabled"))
+	}
+	if err := m.controller.Err(); err != nil {
+		parts = append(parts, "ERROR: "+strings.ReplaceAll(err.Error(), "\n", "; "))
+	} else if !m.controller.Healthy() {
+		parts = append(parts, "DISCONNECTED, reconnecting")
+	}
+	if m.asUser != "" {
+		as := "as: " + m.asUser
+		if len(m.asGroups) > 0 {
+			as += " (groups: " + strings.Join(m.asGroups, ", ") + ")"
+		}
+		parts = append(parts, as)
+	}
+	if namespace := m.controller.Namespace(); namespace != "" {
+		parts = append(parts, "namespace: "+namespace)
+	} else {
+		parts = append(parts, "all namespaces")
+	}
+	if filter := m.filters[m.resource]; filter != "" {
+		parts = append(parts, "filter: "+filter)
+	}
+	if m.resource == deploymentsResource && m.health != showAll {
+		parts = append(parts, "showing "+m.health.String())
+	}
+	if m.resource == deploymentsResource && m.cursor < len(m.choices) {
+		if d, ok := m.deployments[m.choices[m.cursor]]; ok {
+			if explanation := explainUnhealthy(d); explanation != "" {
+				parts = append(parts, "unhealthy: "+explanation)
+			}
+		}
+	}
+	if m.jumping {
+		parts = append(parts, "jump: "+m.jumpBuffer)
+	}
+	if m.paused {
+		parts = append(parts, "PAUSED")
+	}
+	if !m.refreshedAt.IsZero() {
+		parts = append(parts, "refreshed "+formatTime(m.refreshedAt, time.Now(), m.timeFormat))
+	}
+	if m.controller.ReadOnly() {
+		parts = append(parts, "READ-ONLY")
+	}
+	if m.resource == deploymentsResource {
+		parts = append(parts, fmt.Sprintf("sorted by %s %s", m.sortMode, sortArrow(m.sortDesc)))
+	}
+	if m.backlogged() {
+		parts = append(parts, fmt.Sprintf("processing backlog (%d queued), data may be stale", m.backlog))
+	}
+	if m.pending > 0 {
+		parts = append(parts, fmt.Sprintf("%d operation(s) in progress", m.pending))
+	}
+	return strings.Join(parts, " | ")
+}
@@ -0,0 +1,144 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// strategyTypes are offered by the strategy picker.
+var strategyTypes = []appsv1.DeploymentStrategyType{appsv1.RollingUpdateDeploymentStrategyType, appsv1.RecreateDeploymentStrategyType}
+
+// defaultRollingParam is what the API server defaults maxSurge and
+// maxUnavailable to.
+var defaultRollingParam = intstr.FromString("25%")
+
+// rollingParams returns the maxSurge and maxUnavailable of d, defaulted like the
+// API server does.
+func rollingParams(d *appsv1.Deployment) (intstr.IntOrString, intstr.IntOrString) {
+	surge, unavailable := defaultRollingParam, defaultRollingParam
+	if ru := d.Spec.Strategy.RollingUpdate; ru != nil {
+		if ru.MaxSurge != nil {
+			surge = *ru.MaxSurge
+		}
+		if ru.MaxUnavailable != nil {
+			unavailable = *ru.MaxUnavailable
+		}
+	}
+	return surge, unavailable
+}
+
+// strategyDetail renders the update strategy of d.
+func strategyDetail(d *appsv1.Deployment) string {
+	if d.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType {
+		return "Strategy:  Recreate\n"
+	}
+	surge, unavailable := rollingParams(d)
+	return fmt.Sprintf("Strategy:  RollingUpdate (max surge %s, max unavailable %s)\n", surge.String(), unavailable.String())
+}
+
+// editStrategy asks for the update strategy of the deployment under the
+// cursor, then for the rolling update parameters when it is RollingUpdate.
+func (m *model) editStrategy() {
+	namespace, name, ok := m.currentDeployment()
+	if !ok {
+		return
+	}
+	deployment := m.deployments[namespace+"/"+name]
+	if deployment == nil {
+		return
+	}
+
+	names := make([]string, len(strategyTypes))
+	current := 0
+	for i, t := range strategyTypes {
+		names[i] = string(t)
+		if t == deployment.Spec.Strategy.Type {
+			current = i
+		}
+	}
+	m.picker = newPicker("Update strategy", names, current, func(m *model, i int) tea.Cmd {
+		if strategyTypes[i] == appsv1.RecreateDeploymentStrategyType {
+			return m.runOperation(func() error {
+				return m.controller.SetStrategy(namespace, name, appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType})
+			})
+		}
+		m.promptRollingParams(namespace, name, deployment)
+		return textinput.Blink
+	})
+}
+
+// promptRollingParams prompts for the maxSurge and maxUnavailable of the
+// deployment, starting from its current ones.
+func (m *model) promptRollingParams(namespace, name string, deployment *appsv1.Deployment) {
+	surge, unavailable := rollingParams(deployment)
+	m.prompt = newPrompt("Max surge, max unavailable (count or percentage): ", func(m *model, value string) tea.Cmd {
+		if value == "" {
+			return nil
+		}
+		ru, err := parseRollingParams(value)
+		if err != nil {
+			m.showError(err)
+			return nil
+		}
+		return m.runOperation(func() error {
+			return m.controller.SetStrategy(namespace, name, appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: ru,
+			})
+		})
+	})
+	m.prompt.input.SetValue(surge.String() + ", " + unavailable.String())
+}
+
+// parseRollingParams parses comma separated maxSurge and maxUnavailable
+// values, each either a count or a percentage such as 25%.
+func parseRollingParams(value string) (*appsv1.RollingUpdateDeployment, error) {
+	surgeValue, unavailableValue, ok := strings.Cut(value, ",")
+	if !ok {
+		return nil, fmt.Errorf("invalid rolling update %q, expected <max surge>, <max unavailable>", value)
+	}
+	surge, err := parseRollingParam(surgeValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max surge, got err: %w", err)
+	}
+	unavailable, err := parseRollingParam(unavailableValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max unavailable, got err: %w", err)
+	}
+	// The API server rejects a rollout that can neither add nor remove pods
+	if isZero(surge) && isZero(unavailable) {
+		return nil, errors.New("max surge and max unavailable can't both be zero")
+	}
+	return &appsv1.RollingUpdateDeployment{MaxSurge: &surge, MaxUnavailable: &unavailable}, nil
+}
+
+// isZero reports whether value is 0 or 0%.
+func isZero(value intstr.IntOrString) bool {
+	n, err := intstr.GetScaledValueFromIntOrPercent(&value, 100, false)
+	return err == nil && n == 0
+}
+
+// parseRollingParam parses a non negative count, or a percentage between 0%
+// and 100%.
+func parseRollingParam(value string) (intstr.IntOrString, error) {
+	value = strings.TrimSpace(value)
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		n, err := strconv.Atoi(percent)
+		if err != nil || n < 0 || n > 100 {
+			return intstr.IntOrString{}, fmt.Errorf("%q is not a percentage between 0%% and 100%%", value)
+		}
+		return intstr.FromString(value), nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || n < 0 {
+		return intstr.IntOrString{}, fmt.Errorf("%q is not a non negative count or a percentage", value)
+	}
+	return intstr.FromInt32(int32(n)), nil
+}
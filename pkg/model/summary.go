@@ -0,0 +1,37 @@
+package model
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// summary aggregates the replica counts of a set of deployments.
+type summary struct {
+	deployments int
+	desired     int32
+	ready       int32
+	unhealthy   int
+}
+
+// summarize aggregates the deployments stored under keys.
+func summarize(keys []string, deployments map[string]*appsv1.Deployment) summary {
+	var s summary
+	for _, key := range keys {
+		d := deployments[key]
+		if d == nil {
+			continue
+		}
+		s.deployments++
+		s.desired += desiredReplicas(d)
+		s.ready += d.Status.ReadyReplicas
+		if !deploymentHealthy(d) {
+			s.unhealthy++
+		}
+	}
+	return s
+}
+
+func (s summary) String() string {
+	return fmt.Sprintf("%d deployments, %d/%d replicas ready, %d unhealthy", s.deployments, s.ready, s.desired, s.unhealthy)
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func TestSummarize(t *testing.T) {
+	deployments := map[string]*appsv1.Deployment{
+		"a/api":    newDeployment("a/api", 3, 3),
+		"a/web":    newDeployment("a/web", 2, 1),
+		"b/worker": newDeployment("b/worker", 0, 0),
+		"b/batch":  newDeployment("b/batch", 4, 0),
+	}
+	// A deployment without replicas set wants one
+	deployments["b/default"] = newDeployment("b/default", 0, 1)
+	deployments["b/default"].Spec.Replicas = nil
+
+	tests := []struct {
+		name string
+		keys []string
+		want summary
+	}{
+		{"none", nil, summary{}},
+		{"healthy", []string{"a/api", "b/worker"}, summary{deployments: 2, desired: 3, ready: 3}},
+		{"unhealthy", []string{"a/web", "b/batch"}, summary{deployments: 2, desired: 6, ready: 1, unhealthy: 2}},
+		{"default replicas", []string{"b/default"}, summary{deployments: 1, desired: 1, ready: 1}},
+		{"all", []string{"a/api", "a/web", "b/worker", "b/batch", "b/default"}, summary{deployments: 5, desired: 10, ready: 5, unhealthy: 2}},
+		// Keys whose deployment has just been deleted are skipped
+		{"missing", []string{"a/api", "c/gone"}, summary{deployments: 1, desired: 3, ready: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarize(tt.keys, deployments); got != tt.want {
+				t.Errorf("summarize(%v) = %+v, want %+v", tt.keys, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummaryString(t *testing.T) {
+	s := summary{deployments: 2, desired: 6, ready: 1, unhealthy: 2}
+	want := "2 deployments, 1/6 replicas ready, 2 unhealthy"
+	if got := s.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,226 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tableFormat holds the tabwriter settings used to lay out the resource
+// tables.
+type tableFormat struct {
+	minWidth   int  // minimal cell width including padding
+	tabWidth   int  // width of a tab character when padChar is '\t'
+	padding    int  // padding added to each cell
+	padChar    byte // character used for padding
+	alignRight bool // right align the cells instead of left aligning them
+}
+
+// terminatingBadge is appended to the rows of objects with a deletion
+// timestamp. Being the last cell, its styling doesn't upset the alignment of
+// the columns.
+var terminatingBadge = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Strikethrough(true).Render("Terminating")
+
+// defaultTableFormat pads the cells with spaces and left aligns them.
+var defaultTableFormat = tableFormat{
+	tabWidth: 8,
+	padding:  2,
+	padChar:  ' ',
+}
+
+// newWriter returns a tabwriter writing to w with the settings of f.
+func (f tableFormat) newWriter(w io.Writer) *tabwriter.Writer {
+	var flags uint
+	if f.alignRight {
+		flags |= tabwriter.AlignRight
+	}
+	return tabwriter.NewWriter(w, f.minWidth, f.tabWidth, f.padding, f.padChar, flags)
+}
+
+// headerTitles returns the column titles of the active resource.
+func (m model) headerTitles() []string {
+	switch m.resource {
+	case podsResource:
+		return []string{"Namespace", "Pod", "Status", "Node", "IP"}
+	case nodesResource:
+		return []string{"Node", "Status", "Roles", "Version", "CPU", "Memory"}
+	case cronJobsResource:
+		return []string{"Namespace", "CronJob", "Schedule", "Suspended", "Active", "Last Schedule"}
+	case jobsResource:
+		return []string{"Namespace", "Job", "Completions", "Active", "Failed"}
+	case ingressesResource:
+		return []string{"Namespace", "Ingress", "Class", "Hosts", "Address"}
+	case servicesResource:
+		return []string{"Namespace", "Service", "Type", "Cluster IP", "Ports", "Endpoints"}
+	case customResource:
+		return []string{"Namespace", "Name", "Age"}
+	default:
+		titles := columnTitles(m.columns)
+		if i := sortColumn(m.columns, m.sortMode); i >= 0 {
+			titles[i] += " " + sortArrow(m.sortDesc)
+		}
+		return titles
+	}
+}
+
+// rowCells renders the tab separated cells of the row stored under key.
+func (m model) rowCells(key string) string {
+	switch m.resource {
+	case podsResource:
+		return splitTheStringAndAddTabs(podRow(key, m.pods[key]))
+	case nodesResource:
+		return nodeRow(key, m.nodes[key])
+	case cronJobsResource:
+		return splitTheStringAndAddTabs(cronJobRow(key, m.cronJobs[key]))
+	case jobsResource:
+		return splitTheStringAndAddTabs(jobRow(key, m.jobs[key]))
+	case ingressesResource:
+		return splitTheStringAndAddTabs(ingressRow(key, m.ingresses[key]))
+	case servicesResource:
+		return splitTheStringAndAddTabs(serviceRow(key, m.services[key], m.slices))
+	case customResource:
+		return splitTheStringAndAddTabs(customRow(key, m.custom[key], m.verboseAge))
+	default:
+		return columnValues(m.columns, key, m.deployments[key], m.verboseAge)
+	}
+}
+
+// emptyTableMessage explains why the table of the active resource has no
+// rows: either there is no object of that resource or the filters hide them
+// all.
+func (m model) emptyTableMessage() string {
+	filtered := m.filters[m.resource] != ""
+	if m.resource == deploymentsResource && m.health != showAll {
+		filtered = true
+	}
+	if filtered {
+		return fmt.Sprintf("No %s match the current filters.", m.apiName(m.resource))
+	}
+	return fmt.Sprintf("No %s found.", m.apiName(m.resource))
+}
+
+// writeTable writes the rows of the active resource to w, preceded by the
+// column titles and their underlines when headers is set. With marks every
+// row starts with a cell showing the cursor and whether the row is selected.
+// The table is laid out before any row is colored, as the tabwriter would
+// count the color escapes as part of the cells.
+func (m model) writeTable(w io.Writer, headers, marks bool) {
+	var table bytes.Buffer
+	tw := m.table.newWriter(&table)
+	styles, headings := m.layoutTable(tw, headers, marks)
+	tw.Flush()
+
+	lines := strings.SplitAfter(table.String(), "\n")
+	for i, line := range lines {
+		for _, heading := range headings[i] {
+			io.WriteString(w, heading+"\n")
+		}
+		if style, ok := styles[i]; ok {
+			line = style.Render(strings.TrimSuffix(line, "\n")) + "\n"
+		}
+		io.WriteString(w, line)
+	}
+}
+
+// layoutTable writes the rows of the table to w and returns the style of each
+// line to be colored, by line number, along with the group headings to write
+// before each line. The headings are kept out of the tabwriter, which would
+// otherwise align the columns of every group on their own.
+func (m model) layoutTable(w io.Writer, headers, marks bool) (map[int]lipgloss.Style, map[int][]string) {
+	styles := make(map[int]lipgloss.Style)
+	headings := make(map[int][]string)
+	line := 0
+
+	// withMark prepends the mark cell when marks are shown. Right aligned
+	// rows end with a tab, as the tabwriter only pads the cells ending in
+	// one and the last column would otherwise stick to the one before.
+	withMark := func(mark, cells string) string {
+		if m.table.alignRight {
+			cells += "\t"
+		}
+		if !marks {
+			return cells
+		}
+		return mark + "\t" + cells
+	}
+
+	// The header
+	if headers {
+		titles := m.headerTitles()
+		underlines := make([]string, len(titles))
+		for i, title := range titles {
+			underlines[i] = strings.Repeat("-", utf8.RuneCountInString(title))
+		}
+		fmt.Fprintln(w, withMark("", strings.Join(titles, "\t")))
+		fmt.Fprintln(w, withMark("", strings.Join(underlines, "\t")))
+		line += 2
+	}
+
+	// Iterate over our choices
+	group := ""
+	for i, choice := range m.choices {
+
+		// Start a new group when the group-by label changes
+		if m.resource == deploymentsResource && m.groupBy != "" {
+			if g := groupValue(m.deployments[choice], m.groupBy); i == 0 || g != group {
+				group = g
+				headings[line] = append(headings[line], groupHeader(m.groupBy, group))
+
+				// Give the capacity left in the namespace
+				if m.groupBy == groupByNamespace {
+					headings[line] = append(headings[line], quotaLines(group, m.quotas)...)
+				}
+			}
+		}
+
+		// Is the cursor pointing at this choice?
+		cursor := " " // no cursor
+		if m.cursor == i {
+			cursor = ">" // cursor!
+		}
+
+		// Is this choice selected?
+		checked := " " // not selected
+		if _, ok := m.selected[choice]; ok {
+			checked = "x" // selected!
+		}
+
+		// Is this choice pinned?
+		pinned := ""
+		if _, ok := m.pins[choice]; ok && m.resource == deploymentsResource {
+			pinned = pinMark
+		}
+
+		// Flag objects which are being deleted but are held back by
+		// finalizers, and nodes which are cordoned
+		cells := m.rowCells(choice)
+		if width := m.maxCellWidth(); width > 0 {
+			cells = truncateCells(cells, width)
+		}
+		if obj, ok := m.object(choice); ok && obj.GetDeletionTimestamp() != nil {
+			cells += "\t" + terminatingBadge
+		}
+		if node, ok := m.nodes[choice]; ok && m.resource == nodesResource && node.Spec.Unschedulable {
+			cells += "\t" + cordonedBadge
+		}
+
+		// Color pods by phase, and highlight the deployments which have just
+		// changed
+		if pod, ok := m.pods[choice]; ok && m.resource == podsResource {
+			styles[line] = podStyle(pod)
+		}
+		if m.resource == deploymentsResource && m.flashing(choice) {
+			styles[line] = flashStyle
+		}
+
+		// Render the row
+		fmt.Fprintln(w, withMark(fmt.Sprintf("%s [%s]%s", cursor, checked, pinned), cells))
+		line++
+	}
+	return styles, headings
+}
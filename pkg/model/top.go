@@ -0,0 +1,134 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AClarkie/k8s-tui/pkg/controller"
+	tea "github.com/charmbracelet/bubbletea"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// usageMsg carries the pod metrics fetched for the usage view opened as
+// number seq.
+type usageMsg struct {
+	seq     int
+	metrics map[string]*metricsv1beta1.PodMetrics
+	err     error
+}
+
+// openTop opens the usage view of the deployments and starts fetching the
+// metrics of their pods.
+func (m *model) openTop() tea.Cmd {
+	m.top = true
+	m.topSeq++
+	m.usage, m.usageErr = nil, nil
+	return m.fetchUsage(0)
+}
+
+// fetchUsage fetches the pod metrics after delay.
+func (m model) fetchUsage(delay time.Duration) tea.Cmd {
+	c, seq := m.controller, m.topSeq
+	fetch := func() tea.Msg {
+		metrics, err := c.PodMetrics()
+		return usageMsg{seq: seq, metrics: metrics, err: err}
+	}
+	if delay == 0 {
+		return fetch
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return fetch()
+	})
+}
+
+// setUsage keeps the fetched metrics if their usage view is still open, and
+// fetches them again a second later. Without metrics-server there is nothing
+// to fetch again.
+func (m *model) setUsage(msg usageMsg) tea.Cmd {
+	if !m.top || msg.seq != m.topSeq {
+		return nil
+	}
+	m.usage, m.usageErr = msg.metrics, msg.err
+	if errors.Is(msg.err, controller.ErrMetricsUnavailable) {
+		return nil
+	}
+	return m.fetchUsage(time.Second)
+}
+
+// handleTopKey closes the usage view on esc, q or t.
+func (m *model) handleTopKey(key tea.KeyMsg) tea.Cmd {
+	switch key.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc", "q", "t":
+		m.top = false
+	}
+	return nil
+}
+
+// deploymentUsage is the summed usage of the pods of a deployment.
+type deploymentUsage struct {
+	key    string
+	pods   int   // pods with metrics
+	cpu    int64 // in millicores
+	memory int64 // in bytes
+}
+
+// deploymentUsages adds up the container usage of the pods of each listed
+// deployment, the deployments using the most CPU first.
+func (m model) deploymentUsages() []deploymentUsage {
+	var usages []deploymentUsage
+	for _, key := range m.filteredKeys(deploymentsResource) {
+		d := m.deployments[key]
+		usage := deploymentUsage{key: key}
+		for _, pod := range deploymentPods(d, m.pods) {
+			metrics, ok := m.usage[pod.Namespace+"/"+pod.Name]
+			if !ok {
+				continue
+			}
+			usage.pods++
+			for _, container := range metrics.Containers {
+				usage.cpu += container.Usage.Cpu().MilliValue()
+				usage.memory += container.Usage.Memory().Value()
+			}
+		}
+		usages = append(usages, usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].cpu != usages[j].cpu {
+			return usages[i].cpu > usages[j].cpu
+		}
+		return usages[i].key < usages[j].key
+	})
+	return usages
+}
+
+// topView renders the CPU and memory used by the pods of each deployment, like
+// kubectl top.
+func (m model) topView() string {
+	var builder strings.Builder
+	builder.WriteString("Resource usage of the deployments\n\n")
+
+	switch {
+	case errors.Is(m.usageErr, controller.ErrMetricsUnavailable):
+		builder.WriteString("Metrics are unavailable, metrics-server isn't installed in this cluster.\n")
+	case m.usageErr != nil:
+		fmt.Fprintf(&builder, "Failed to fetch the metrics: %v\n", m.usageErr)
+	case m.usage == nil:
+		builder.WriteString("Fetching the metrics...\n")
+	default:
+		writer := m.table.newWriter(&builder)
+		fmt.Fprintln(writer, "Namespace\tDeployment\tPods\tCPU\tMemory")
+		fmt.Fprintln(writer, "---------\t----------\t----\t---\t------")
+		for _, usage := range m.deploymentUsages() {
+			fmt.Fprintf(writer, "%s\t%d\t%dm\t%dMi\n", splitTheStringAndAddTabs(usage.key), usage.pods, usage.cpu, usage.memory/(1024*1024))
+		}
+		writer.Flush()
+	}
+
+	builder.WriteString("\nPress esc to go back.")
+	return builder.String()
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	// ellipsis replaces the end of truncated cells.
+	ellipsis = "…"
+	// minCellWidth is the narrowest a cell is ever truncated to.
+	minCellWidth = 8
+	// cellWidthStep is how much the "<" and ">" keys change the cell width by.
+	cellWidthStep = 4
+)
+
+// truncate shortens s to at most width terminal columns, ending it with an
+// ellipsis when anything was cut. It never splits a multibyte character and
+// accounts for wide ones. Widths too narrow for the ellipsis get as much of
+// s as fits instead.
+func truncate(s string, width int) string {
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+
+	mark := ellipsis
+	limit := width - runewidth.StringWidth(mark)
+	if limit < 0 {
+		mark, limit = "", width
+	}
+	var builder strings.Builder
+	used := 0
+	for _, r := range s {
+		w := runewidth.RuneWidth(r)
+		if used+w > limit {
+			break
+		}
+		builder.WriteRune(r)
+		used += w
+	}
+	builder.WriteString(mark)
+	return builder.String()
+}
+
+// truncateCells truncates every tab separated cell of row to width.
+func truncateCells(row string, width int) string {
+	cells := strings.Split(row, "\t")
+	for i, cell := range cells {
+		cells[i] = truncate(cell, width)
+	}
+	return strings.Join(cells, "\t")
+}
+
+// maxCellWidth returns the width table cells are truncated to, or zero when
+// they are never truncated. Unless set with the "<" and ">" keys it is a
+// third of the terminal width, so that a name and a couple of other columns
+// fit.
+func (m model) maxCellWidth() int {
+	if m.cellWidth > 0 {
+		return m.cellWidth
+	}
+	if m.width == 0 {
+		return 0
+	}
+	return max(m.width/3, minCellWidth)
+}
+
+// resizeCells changes the width table cells are truncated to by delta.
+func (m *model) resizeCells(delta int) {
+	width := m.maxCellWidth()
+	if width == 0 {
+		return
+	}
+	m.cellWidth = max(width+delta, minCellWidth)
+}
+
+// toggleReveal shows or hides the full key of the row under the cursor.
+func (m *model) toggleReveal() {
+	if m.revealed() || m.cursor >= len(m.choices) {
+		m.revealKey = ""
+		return
+	}
+	m.revealKey = m.choices[m.cursor]
+}
+
+// revealed reports whether the full key of the row under the cursor is
+// shown. Moving the cursor to another row hides it again.
+func (m model) revealed() bool {
+	return m.revealKey != "" && m.cursor < len(m.choices) && m.choices[m.cursor] == m.revealKey
+}
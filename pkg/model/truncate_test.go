@@ -0,0 +1,47 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"fits", "web", 3, "web"},
+		{"cut", "frontend", 5, "fron…"},
+		{"multibyte", "déploiement", 4, "dép…"},
+		{"wide", "日本語の名前", 7, "日本語…"},
+		// A wide rune never straddles the limit
+		{"wide straddling", "日本語の名前", 6, "日本…"},
+		{"only ellipsis", "frontend", 1, "…"},
+		{"narrower than ellipsis", "frontend", 0, ""},
+		{"negative", "frontend", -1, ""},
+		{"wide only ellipsis", "日本語", 2, "…"},
+		{"empty", "", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.s, tt.width)
+			if got != tt.want {
+				t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+			if w := runewidth.StringWidth(got); w > max(tt.width, 0) {
+				t.Errorf("truncate(%q, %d) is %d columns wide", tt.s, tt.width, w)
+			}
+		})
+	}
+}
+
+func TestTruncateCells(t *testing.T) {
+	got := truncateCells("default/frontend\t日本語の名前\t1/1", 5)
+	want := "defa…\t日本…\t1/1"
+	if got != want {
+		t.Errorf("truncateCells() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,180 @@
+package model
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the View tests")
+
+// assertGolden compares got with testdata/name.golden, or rewrites the file
+// with -update.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("failed to update %s, got err: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s, run with -update to create it, got err: %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("View() doesn't match %s, run with -update if the change is intended\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// renderView renders the view of m with the colors stripped, so that the
+// golden files don't depend on the terminal.
+func renderView(m model) string {
+	m.state = ready
+	m.refreshChoices()
+	return ansi.Strip(m.View())
+}
+
+// viewDeployments are the deployments of the golden tests, with names and
+// ready counts of varying widths.
+func viewDeployments() map[string]*appsv1.Deployment {
+	return map[string]*appsv1.Deployment{
+		"default/api":             newDeployment("default/api", 3, 3),
+		"default/web":             newDeployment("default/web", 10, 7),
+		"kube-system/coredns":     newDeployment("kube-system/coredns", 2, 2),
+		"monitoring/grafana":      newDeployment("monitoring/grafana", 1, 0),
+		"monitoring/alertmanager": newDeployment("monitoring/alertmanager", 0, 0),
+	}
+}
+
+// viewFixture are the deployments of the layout golden tests: mixed ready
+// ratios, ages from seconds to years and names too long for their cells.
+func viewFixture() map[string]*appsv1.Deployment {
+	deployments := map[string]*appsv1.Deployment{
+		"default/api":         newDeployment("default/api", 3, 3),
+		"default/web":         newDeployment("default/web", 10, 7),
+		"kube-system/coredns": newDeployment("kube-system/coredns", 2, 0),
+		"payments/checkout-service-canary-with-a-very-long-name": newDeployment("payments/checkout-service-canary-with-a-very-long-name", 1, 1),
+		"a-namespace-whose-name-is-quite-long/worker":            newDeployment("a-namespace-whose-name-is-quite-long/worker", 0, 0),
+	}
+	// Half a second past each age, so that the ages don't change while the
+	// test runs
+	ages := map[string]time.Duration{
+		"default/api":         42 * time.Second,
+		"default/web":         5*time.Hour + 12*time.Minute,
+		"kube-system/coredns": 3*day + 4*time.Hour,
+		"payments/checkout-service-canary-with-a-very-long-name": 17 * time.Minute,
+		"a-namespace-whose-name-is-quite-long/worker":            2*year + 40*day,
+	}
+	now := time.Now()
+	for key, age := range ages {
+		deployments[key].CreationTimestamp = meta_v1.NewTime(now.Add(-age - 500*time.Millisecond))
+	}
+	return deployments
+}
+
+// viewPods are the pods of the golden tests, one of them not scheduled yet.
+func viewPods() map[string]*corev1.Pod {
+	return map[string]*corev1.Pod{
+		"default/api-7d9c5": {
+			ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "api-7d9c5"},
+			Spec:       corev1.PodSpec{NodeName: "node-1"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.12"},
+		},
+		"kube-system/coredns-5f4b8": {
+			ObjectMeta: meta_v1.ObjectMeta{Namespace: "kube-system", Name: "coredns-5f4b8"},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		},
+	}
+}
+
+// viewNodes are the cluster scoped nodes of the golden tests.
+func viewNodes() map[string]*corev1.Node {
+	node := func(name, version string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Labels: map[string]string{"node-role.kubernetes.io/control-plane": ""}},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+				NodeInfo:   corev1.NodeSystemInfo{KubeletVersion: version},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    apiresource.MustParse("4"),
+					corev1.ResourceMemory: apiresource.MustParse("16Gi"),
+				},
+			},
+		}
+	}
+	return map[string]*corev1.Node{
+		"control-plane": node("control-plane", "v1.31.1", corev1.ConditionTrue),
+		"node-1":        node("node-1", "v1.30.4", corev1.ConditionFalse),
+	}
+}
+
+func TestViewGolden(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []Option
+		setup func(m *model)
+	}{
+		{name: "default"},
+		{name: "align_right", opts: []Option{WithAlignRight(true)}},
+		{name: "padding", opts: []Option{WithTablePadding(5, ' ')}},
+		// Long names are truncated to a third of the terminal width
+		{name: "fixture", opts: []Option{WithColumns("namespace,name,ready,age")}, setup: func(m *model) {
+			m.deployments = viewFixture()
+		}},
+		{name: "fixture_wide", opts: []Option{WithColumns("namespace,name,ready,age"), WithVerboseAge(true)}, setup: func(m *model) {
+			m.deployments = viewFixture()
+			m.width = 200
+		}},
+		{name: "fixture_ready", opts: []Option{WithColumns("namespace,name,ready,age")}, setup: func(m *model) {
+			m.deployments = viewFixture()
+			m.sortMode = sortByReady
+		}},
+		// The headers of every tab line up with its rows
+		{name: "pods", opts: []Option{WithResource("pods")}, setup: func(m *model) {
+			m.pods = viewPods()
+		}},
+		{name: "nodes", opts: []Option{WithResource("nodes")}, setup: func(m *model) {
+			m.nodes = viewNodes()
+		}},
+		// The column the rows are sorted by carries the sort direction
+		{name: "sort_name_desc", setup: func(m *model) {
+			m.sortDesc = true
+		}},
+		{name: "sort_ready", setup: func(m *model) {
+			m.sortMode = sortByReady
+		}},
+		{name: "sort_ready_desc", setup: func(m *model) {
+			m.sortMode, m.sortDesc = sortByReady, true
+		}},
+		// The health sort has no column of its own
+		{name: "sort_health", setup: func(m *model) {
+			m.sortMode = sortByHealth
+		}},
+		{name: "sort_ready_hidden", opts: []Option{WithColumns("name,namespace")}, setup: func(m *model) {
+			m.sortMode = sortByReady
+		}},
+		{name: "columns", opts: []Option{WithColumns("name,label:team,namespace")}, setup: func(m *model) {
+			withLabel(m.deployments["default/web"], "team", "frontend")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, tt.opts...)
+			m.deployments = viewDeployments()
+			if tt.setup != nil {
+				tt.setup(&m)
+			}
+			assertGolden(t, "view_"+tt.name, renderView(m))
+		})
+	}
+}